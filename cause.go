@@ -0,0 +1,37 @@
+package ifrit
+
+import "os"
+
+/*
+SignalWithCause sends a signal to a Process along with the reason it is being
+stopped, such as "member db exited" or "operator requested shutdown".  Like
+context cancellation, only the first cause sticks: the first signal a Process
+receives determines its cause, which is reported in its Status and can be
+retrieved by its Runner with Cause.  A nil cause behaves like Signal.
+*/
+func SignalWithCause(p Process, signal os.Signal, cause error) {
+	if cp, ok := p.(*process); ok {
+		cp.signalWithCause(signal, cause)
+		return
+	}
+	p.Signal(signal)
+}
+
+/*
+Cause returns the reason the Process which owns the signals channel was
+signaled.  If the Process was signaled without a cause, Cause returns an
+ErrSignaled for its first signal.  Cause returns nil if the Process has not
+been signaled, or if the channel does not belong to a running Process.
+
+Runners which propagate signals to child processes can pass the cause along:
+
+	case sig := <-signals:
+		ifrit.SignalWithCause(child, sig, ifrit.Cause(signals))
+*/
+func Cause(signals <-chan os.Signal) error {
+	p, ok := runningProcesses.Load(signals)
+	if !ok {
+		return nil
+	}
+	return p.(*process).Status().Cause
+}
@@ -0,0 +1,69 @@
+package ifrit_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"syscall"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/tedsuo/ifrit"
+)
+
+var _ = Describe("Cause", func() {
+	var causes chan error
+	var runner ifrit.Runner
+
+	BeforeEach(func() {
+		causes = make(chan error, 1)
+		runner = ifrit.RunFunc(func(signals <-chan os.Signal, ready chan<- struct{}) error {
+			close(ready)
+			<-signals
+			causes <- ifrit.Cause(signals)
+			return nil
+		})
+	})
+
+	It("is the cause the runner was signaled with", func() {
+		cause := errors.New("operator requested shutdown")
+		proc := ifrit.Invoke(runner)
+		ifrit.SignalWithCause(proc, os.Interrupt, cause)
+
+		Eventually(causes).Should(Receive(Equal(cause)))
+		Ω(proc.Status().Cause).Should(Equal(cause))
+	})
+
+	It("is an ErrSignaled when the runner was signaled without a cause", func() {
+		proc := ifrit.Invoke(runner)
+		proc.Signal(syscall.SIGUSR2)
+
+		Eventually(causes).Should(Receive(Equal(ifrit.ErrSignaled{Signal: syscall.SIGUSR2})))
+	})
+
+	It("is determined by the first signal", func() {
+		first := errors.New("first")
+		proc := ifrit.Invoke(runner)
+		ifrit.SignalWithCause(proc, os.Interrupt, first)
+		ifrit.SignalWithCause(proc, os.Kill, errors.New("second"))
+
+		Eventually(causes).Should(Receive(Equal(first)))
+	})
+
+	It("is nil for a channel which does not belong to a process", func() {
+		Ω(ifrit.Cause(make(chan os.Signal))).Should(BeNil())
+	})
+
+	It("is given to the context of a RunContextFunc", func() {
+		cause := errors.New("operator requested shutdown")
+		proc := ifrit.Invoke(ifrit.RunContextFunc(func(ctx context.Context, ready func()) error {
+			ready()
+			<-ctx.Done()
+			causes <- context.Cause(ctx)
+			return nil
+		}))
+		ifrit.SignalWithCause(proc, os.Interrupt, cause)
+
+		Eventually(causes).Should(Receive(Equal(cause)))
+	})
+})
@@ -0,0 +1,91 @@
+package ifrit
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+)
+
+/*
+The RunContextFunc type is an adapter to allow the use of context-aware
+functions as Runners. The function is handed a context which is cancelled when
+the Runner receives its first signal, and a ready callback which closes the
+ready channel. The callback may be called more than once.
+
+The reason the context was cancelled can be recovered with context.Cause,
+which returns the signal's cause (see SignalWithCause), or an ErrSignaled.
+*/
+type RunContextFunc func(ctx context.Context, ready func()) error
+
+func (r RunContextFunc) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	ctx, cancel := context.WithCancelCause(context.Background())
+	defer cancel(nil)
+
+	readyOnce := new(sync.Once)
+	markReady := func() {
+		readyOnce.Do(func() {
+			close(ready)
+		})
+	}
+
+	exit := make(chan error, 1)
+	go func() {
+		exit <- r(ctx, markReady)
+	}()
+
+	for {
+		select {
+		case sig := <-signals:
+			cause := Cause(signals)
+			if cause == nil {
+				cause = ErrSignaled{Signal: sig}
+			}
+			cancel(cause)
+			signals = nil
+		case err := <-exit:
+			return err
+		}
+	}
+}
+
+/*
+ErrSignaled is the cause given to the context of a RunContextFunc when the
+Runner is signaled.
+*/
+type ErrSignaled struct {
+	Signal os.Signal
+}
+
+func (e ErrSignaled) Error() string {
+	return fmt.Sprintf("signaled: %s", e.Signal)
+}
+
+/*
+RunWithContext runs a Runner under a parent context. When the context is done,
+the Runner is sent the given signal, with the context's cause. Signals received
+by the returned Runner are forwarded as usual.
+*/
+func RunWithContext(ctx context.Context, signal os.Signal, runner Runner) Runner {
+	return RunFunc(func(signals <-chan os.Signal, ready chan<- struct{}) error {
+		process := Background(runner, ChildOf(signals))
+		processReady := process.Ready()
+		exit := process.Done()
+		done := ctx.Done()
+
+		for {
+			select {
+			case sig := <-signals:
+				SignalWithCause(process, sig, Cause(signals))
+			case <-done:
+				SignalWithCause(process, signal, context.Cause(ctx))
+				done = nil
+			case <-processReady:
+				close(ready)
+				processReady = nil
+			case <-exit:
+				return process.Err()
+			}
+		}
+	})
+}
@@ -0,0 +1,90 @@
+package ifrit_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"syscall"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/tedsuo/ifrit"
+	"github.com/tedsuo/ifrit/test_helpers"
+)
+
+var _ = Describe("Context", func() {
+	Describe("RunContextFunc", func() {
+		var causes chan error
+		var proc ifrit.Process
+
+		BeforeEach(func() {
+			causes = make(chan error, 1)
+			proc = ifrit.Invoke(ifrit.RunContextFunc(func(ctx context.Context, ready func()) error {
+				ready()
+				ready()
+				<-ctx.Done()
+				causes <- context.Cause(ctx)
+				return nil
+			}))
+		})
+
+		It("becomes ready when the ready callback is called", func() {
+			Ω(proc.Ready()).Should(BeClosed())
+			proc.Signal(os.Interrupt)
+			Eventually(proc.Wait()).Should(Receive(BeNil()))
+		})
+
+		It("cancels the context with the first signal as the cause", func() {
+			proc.Signal(syscall.SIGUSR2)
+
+			var cause error
+			Eventually(causes).Should(Receive(&cause))
+			Ω(cause).Should(Equal(ifrit.ErrSignaled{Signal: syscall.SIGUSR2}))
+			Eventually(proc.Wait()).Should(Receive(BeNil()))
+		})
+	})
+
+	Describe("RunWithContext", func() {
+		var ctx context.Context
+		var cancel context.CancelFunc
+		var recorder *test_helpers.SignalRecoder
+		var proc ifrit.Process
+
+		BeforeEach(func() {
+			ctx, cancel = context.WithCancel(context.Background())
+			recorder = test_helpers.NewSignalRecorder()
+			proc = ifrit.Invoke(ifrit.RunWithContext(ctx, os.Interrupt, recorder))
+		})
+
+		AfterEach(func() {
+			cancel()
+		})
+
+		It("forwards signals to the runner", func() {
+			proc.Signal(syscall.SIGUSR2)
+			Eventually(recorder.ReceivedSignals).Should(ConsistOf(syscall.SIGUSR2))
+		})
+
+		It("signals the runner when the context is done", func() {
+			cancel()
+			Eventually(proc.Wait()).Should(Receive(BeNil()))
+			Ω(recorder.ReceivedSignals()).Should(ConsistOf(os.Interrupt))
+		})
+	})
+
+	Describe("Process.Context", func() {
+		It("is cancelled with the exit error as the cause", func() {
+			proc := ifrit.Invoke(test_helpers.NoReadyRunner)
+			Eventually(proc.Context().Done()).Should(BeClosed())
+			Ω(context.Cause(proc.Context())).Should(Equal(test_helpers.NoReadyExitedNormally))
+		})
+
+		It("is cancelled with context.Canceled after a nil exit", func() {
+			proc := ifrit.Invoke(ifrit.RunFunc(func(signals <-chan os.Signal, ready chan<- struct{}) error {
+				return nil
+			}))
+			Eventually(proc.Context().Done()).Should(BeClosed())
+			Ω(errors.Is(context.Cause(proc.Context()), context.Canceled)).Should(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,62 @@
+package ifrit
+
+import (
+	"errors"
+	"os"
+	"time"
+)
+
+// ErrAbandoned is returned by Escalation.Stop when a Process outlives every step.
+var ErrAbandoned = errors.New("process abandoned: did not exit after escalation")
+
+/*
+An Escalation is a graceful-then-forced shutdown policy, such as "send
+Interrupt, wait 30s, send Kill, wait 5s".  The steps are followed in order
+until the Process exits.
+*/
+type Escalation []EscalationStep
+
+/*
+An EscalationStep sends Signal to a Process, then waits up to Grace for it to
+exit.  A nil Signal only waits.  A zero Grace waits forever.
+*/
+type EscalationStep struct {
+	Signal os.Signal
+	Grace  time.Duration
+}
+
+/*
+Stop follows the escalation until the Process exits, and returns its exit
+error.  If the Process is still running once the final step's Grace has
+elapsed, the Process is abandoned and Stop returns ErrAbandoned.  An empty
+Escalation waits for the Process to exit without signaling it.
+*/
+func (e Escalation) Stop(p Process) error {
+	exit := p.Done()
+
+	for _, step := range e {
+		if step.Signal != nil {
+			p.Signal(step.Signal)
+		}
+
+		if step.Grace <= 0 {
+			<-exit
+			return p.Err()
+		}
+
+		timer := time.NewTimer(step.Grace)
+		select {
+		case <-exit:
+			timer.Stop()
+			return p.Err()
+		case <-timer.C:
+		}
+	}
+
+	if len(e) == 0 {
+		<-exit
+		return p.Err()
+	}
+
+	return ErrAbandoned
+}
@@ -0,0 +1,57 @@
+package ifrit_test
+
+import (
+	"os"
+	"syscall"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/tedsuo/ifrit"
+	"github.com/tedsuo/ifrit/test_helpers"
+)
+
+var _ = Describe("Escalation", func() {
+	var recorder *test_helpers.SignalRecoder
+	var proc ifrit.Process
+
+	BeforeEach(func() {
+		recorder = test_helpers.NewSignalRecorder()
+		proc = ifrit.Invoke(recorder)
+	})
+
+	AfterEach(func() {
+		proc.Signal(os.Kill)
+		Eventually(proc.Wait()).Should(Receive())
+	})
+
+	It("sends each signal in turn until the process exits", func() {
+		escalation := ifrit.Escalation{
+			{Signal: syscall.SIGUSR1, Grace: 10 * time.Millisecond},
+			{Signal: syscall.SIGUSR2, Grace: 10 * time.Millisecond},
+			{Signal: os.Interrupt, Grace: time.Second},
+		}
+
+		Ω(escalation.Stop(proc)).Should(Succeed())
+		Ω(recorder.ReceivedSignals()).Should(Equal([]os.Signal{syscall.SIGUSR1, syscall.SIGUSR2, os.Interrupt}))
+	})
+
+	It("waits without signaling for steps with a nil signal", func() {
+		escalation := ifrit.Escalation{
+			{Grace: 10 * time.Millisecond},
+			{Signal: os.Interrupt},
+		}
+
+		Ω(escalation.Stop(proc)).Should(Succeed())
+		Ω(recorder.ReceivedSignals()).Should(Equal([]os.Signal{os.Interrupt}))
+	})
+
+	It("abandons the process once the final grace period elapses", func() {
+		escalation := ifrit.Escalation{
+			{Signal: syscall.SIGUSR1, Grace: 10 * time.Millisecond},
+		}
+
+		Ω(escalation.Stop(proc)).Should(Equal(ifrit.ErrAbandoned))
+		Ω(proc.State()).Should(Equal(ifrit.Stopping))
+	})
+})
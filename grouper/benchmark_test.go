@@ -0,0 +1,71 @@
+package grouper_test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/tedsuo/ifrit"
+	"github.com/tedsuo/ifrit/grouper"
+)
+
+var benchmarkSizes = []int{10, 100, 1000}
+
+func idleRunner() ifrit.Runner {
+	return ifrit.RunFunc(func(signals <-chan os.Signal, ready chan<- struct{}) error {
+		close(ready)
+		<-signals
+		return nil
+	})
+}
+
+func benchmarkMembers(n int) grouper.Members {
+	members := make(grouper.Members, n)
+	for i := range members {
+		members[i] = grouper.Member{Name: fmt.Sprintf("member-%d", i), Runner: idleRunner()}
+	}
+	return members
+}
+
+// benchmarkGroup starts and stops a group of each size.
+func benchmarkGroup(b *testing.B, newGroup func(grouper.Members) ifrit.Runner) {
+	for _, size := range benchmarkSizes {
+		b.Run(fmt.Sprintf("%d members", size), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				process := ifrit.Invoke(newGroup(benchmarkMembers(size)))
+				process.Signal(os.Interrupt)
+				err := <-process.Wait()
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkParallelGroup(b *testing.B) {
+	benchmarkGroup(b, func(members grouper.Members) ifrit.Runner {
+		return grouper.NewParallel(os.Interrupt, members)
+	})
+}
+
+func BenchmarkOrderedGroup(b *testing.B) {
+	benchmarkGroup(b, func(members grouper.Members) ifrit.Runner {
+		return grouper.NewOrdered(os.Interrupt, members)
+	})
+}
+
+func BenchmarkQueueOrderedGroup(b *testing.B) {
+	benchmarkGroup(b, func(members grouper.Members) ifrit.Runner {
+		return grouper.NewQueueOrdered(os.Interrupt, members)
+	})
+}
+
+func BenchmarkDAGGroup(b *testing.B) {
+	benchmarkGroup(b, func(members grouper.Members) ifrit.Runner {
+		for i := 1; i < len(members); i++ {
+			members[i].DependsOn = []string{members[(i-1)/2].Name}
+		}
+		return grouper.NewDAG(os.Interrupt, members)
+	})
+}
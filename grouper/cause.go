@@ -0,0 +1,37 @@
+package grouper
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/tedsuo/ifrit"
+)
+
+/*
+ErrMemberExited is the cause given to the remaining members of a group when
+one member exits before the group is signaled.
+*/
+type ErrMemberExited struct {
+	Name string
+	Err  error
+}
+
+func (e ErrMemberExited) Error() string {
+	if e.Err == nil {
+		return fmt.Sprintf("member %s exited", e.Name)
+	}
+	return fmt.Sprintf("member %s exited with error: %s", e.Name, e.Err)
+}
+
+func (e ErrMemberExited) Unwrap() error {
+	return e.Err
+}
+
+// shutdownCause explains why a group is stopping: either a member has exited
+// on its own, or the group itself was signaled.
+func shutdownCause(signals <-chan os.Signal, errTrace ErrorTrace) error {
+	if exit, found := errTrace.firstIntolerable(); found {
+		return ErrMemberExited{Name: exit.Member.Name, Err: exit.Err}
+	}
+	return ifrit.Cause(signals)
+}
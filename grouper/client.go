@@ -1,11 +1,220 @@
 package grouper
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
 	"sync"
 
 	"github.com/tedsuo/ifrit"
 )
 
+/*
+StaticClient provides event notifications for a static group, and access to its
+running members.
+*/
+type StaticClient interface {
+
+	/*
+	   EntranceListener provides a new buffered channel of entrance events, which are
+	   emited every time a member becomes ready. Every new channel is populated with
+	   previously emited events. The channel is closed once the group exits.
+	*/
+	EntranceListener() <-chan EntranceEvent
+
+	/*
+	   ExitListener provides a new buffered channel of exit events, which are emited
+	   every time a member exits, or is given up on. Every new channel is populated
+	   with previously emited events. The channel is closed once the group exits.
+	*/
+	ExitListener() <-chan ExitEvent
+
+	/*
+	   Get returns the Process of a running member.  A path such as
+	   "services/api/http" names a member of a nested group, and is followed
+	   through every group along the way.
+	*/
+	Get(name string) (ifrit.Process, bool)
+
+	/*
+	   Tree returns the running members and the state of their processes,
+	   including the members of nested groups.
+	*/
+	Tree() Tree
+
+	/*
+	   Selected returns the running members matching the selector, sorted by
+	   name.
+	*/
+	Selected(selector Selector) []Member
+
+	/*
+	   SignalSelected sends a signal to every running member matching the
+	   selector, and returns their names.  A member which exits is handled
+	   according to its Policy, so members which are drained ahead of the rest of
+	   the group should be Optional.
+	*/
+	SignalSelected(selector Selector, signal os.Signal) []string
+
+	/*
+	   SelectedEntranceListener is an EntranceListener which only receives the
+	   events of members matching the selector.
+	*/
+	SelectedEntranceListener(selector Selector) <-chan EntranceEvent
+
+	/*
+	   SelectedExitListener is an ExitListener which only receives the events of
+	   members matching the selector.
+	*/
+	SelectedExitListener(selector Selector) <-chan ExitEvent
+}
+
+/*
+staticClient implements StaticClient.
+*/
+type staticClient struct {
+	lock                *sync.RWMutex
+	running             map[string]runningMember
+	entranceBroadcaster *entranceEventBroadcaster
+	exitBroadcaster     *exitEventBroadcaster
+}
+
+type runningMember struct {
+	Member
+	process ifrit.Process
+}
+
+// newStaticClient buffers every event, since each member enters and exits
+// at most once.
+func newStaticClient(numMembers int) staticClient {
+	return staticClient{
+		lock:                new(sync.RWMutex),
+		running:             make(map[string]runningMember),
+		entranceBroadcaster: newEntranceEventBroadcaster(numMembers),
+		exitBroadcaster:     newExitEventBroadcaster(numMembers),
+	}
+}
+
+func (c staticClient) Get(name string) (ifrit.Process, bool) {
+	member, ok := c.lookup(name)
+	if ok {
+		return member.process, true
+	}
+	return getPath(c, name)
+}
+
+func (c staticClient) lookup(name string) (runningMember, bool) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	member, ok := c.running[name]
+	return member, ok
+}
+
+func (c staticClient) runningMembers() []runningMember {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	return c.selected(nil)
+}
+
+func (c staticClient) Tree() Tree {
+	return newTree(c)
+}
+
+func (c staticClient) Selected(selector Selector) []Member {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	members := []Member{}
+	for _, member := range c.selected(selector) {
+		members = append(members, member.Member)
+	}
+	return members
+}
+
+func (c staticClient) SignalSelected(selector Selector, signal os.Signal) []string {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	names := []string{}
+	for _, member := range c.selected(selector) {
+		member.process.Signal(signal)
+		names = append(names, member.Name)
+	}
+	return names
+}
+
+// selected returns the running members matching the selector, sorted by name.
+// The caller must hold the lock.
+func (c staticClient) selected(selector Selector) []runningMember {
+	members := []runningMember{}
+	for _, member := range c.running {
+		if selector.Matches(member.Labels) {
+			members = append(members, member)
+		}
+	}
+	sort.Slice(members, func(i, j int) bool {
+		return members[i].Name < members[j].Name
+	})
+	return members
+}
+
+func (c staticClient) started(member Member, process ifrit.Process) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.running[member.Name] = runningMember{Member: member, process: process}
+}
+
+func (c staticClient) exited(event ExitEvent) {
+	c.lock.Lock()
+	delete(c.running, event.Member.Name)
+	c.lock.Unlock()
+
+	c.exitBroadcaster.Broadcast(event)
+}
+
+func (c staticClient) EntranceListener() <-chan EntranceEvent {
+	return c.entranceBroadcaster.Attach()
+}
+
+func (c staticClient) broadcastEntrance(event EntranceEvent) {
+	c.entranceBroadcaster.Broadcast(event)
+}
+
+func (c staticClient) ExitListener() <-chan ExitEvent {
+	return c.exitBroadcaster.Attach()
+}
+
+func (c staticClient) SelectedEntranceListener(selector Selector) <-chan EntranceEvent {
+	return selectEntrances(c.EntranceListener(), selector)
+}
+
+func (c staticClient) SelectedExitListener(selector Selector) <-chan ExitEvent {
+	return selectExits(c.ExitListener(), selector)
+}
+
+func (c staticClient) closeBroadcasters() {
+	c.entranceBroadcaster.Close()
+	c.exitBroadcaster.Close()
+}
+
+// reset prepares the client for another run of its group, reopening the
+// broadcasters the previous run closed.
+func (c staticClient) reset() {
+	c.lock.Lock()
+	for name := range c.running {
+		delete(c.running, name)
+	}
+	c.lock.Unlock()
+
+	c.entranceBroadcaster.Reopen()
+	c.exitBroadcaster.Reopen()
+}
+
 /*
 DynamicClient provides a client with group controls and event notifications.
 A client can use the insert channel to add members to the group. When the group
@@ -38,10 +247,28 @@ type DynamicClient interface {
 	/*
 	   Inserter provides an unbuffered channel for adding members to a group. When the
 	   group becomes full, the insert channel blocks until a running process exits.
-	   Once the group is closed, insert channels block forever.
+	   Once the group is closed, insert channels block forever. A member whose name
+	   is already running is not started; instead, an exit event is emitted for it
+	   with an ErrDuplicateName.
 	*/
 	Inserter() chan<- Member
 
+	/*
+	   Insert adds a member to the group, waiting until the group has room for it.
+	   It returns ErrGroupClosed if the group is closed, ErrDuplicateName if a
+	   member with the same name is running, or the context's error if the context
+	   is done first.
+	*/
+	Insert(ctx context.Context, member Member) error
+
+	/*
+	   TryInsert adds a member to the group without waiting. It returns
+	   ErrGroupFull if the group has no room, ErrGroupClosed if the group is closed,
+	   ErrGroupNotRunning if the group has not started running, or
+	   ErrDuplicateName if a member with the same name is running.
+	*/
+	TryInsert(member Member) error
+
 	/*
 	   Close causes a dynamic group to become a static group. This means that no new
 	   members may be inserted, and the group will exit once all members have
@@ -49,60 +276,384 @@ type DynamicClient interface {
 	*/
 	Close()
 
+	/*
+	   Get returns the Process of a running member.  A path such as
+	   "services/api/http" names a member of a nested group, and is followed
+	   through every group along the way.
+	*/
 	Get(name string) (ifrit.Process, bool)
+
+	/*
+	   Tree returns the members and the state of their processes, including the
+	   members of nested groups.  Once the group has exited, Tree returns nil.
+	*/
+	Tree() Tree
+
+	/*
+	   Members returns a snapshot of every member in the group, in the order they
+	   were inserted.  Once the group has exited, Members returns nil.
+	*/
+	Members() []MemberStatus
+
+	/*
+	   Signal sends a signal to a single member, without signaling the rest of the
+	   group.  It returns ErrMemberNotFound if the member is not running.
+	*/
+	Signal(name string, signal os.Signal) error
+
+	/*
+	   Remove stops a single member and waits for it to exit, returning its exit
+	   error.  The member is sent its StopSignal, or the group's termination signal,
+	   or os.Interrupt, and its exit does not cause the group to propogate the
+	   termination signal.  It returns ErrMemberNotFound if the member is not
+	   running.
+	*/
+	Remove(name string) error
+
+	/*
+	   Replace starts a new process for a member with the given runner, waits for it
+	   to become ready, and then stops the member's old process as Remove would.
+	   Replace returns once the old process has exited.  If the new process exits
+	   before becoming ready, the old process is left running and Replace returns
+	   an ErrMemberExited.  While a member is being replaced, the group may run one
+	   more process than its capacity.
+	*/
+	Replace(name string, runner ifrit.Runner) error
+
+	/*
+	   SetCapacity changes the maximum number of members the group runs at once.
+	   Raising the capacity immediately admits waiting inserts.  Lowering it stops
+	   admitting new members until enough members have exited; the shrink policy
+	   decides whether members above the new capacity are left to exit on their
+	   own, or are stopped as Remove would, with an ErrCapacityReduced cause.
+	*/
+	SetCapacity(capacity int, shrink Shrink) error
+
+	/*
+	   Selected returns the members matching the selector, in the order they were
+	   inserted.  Once the group has exited, Selected returns nil.
+	*/
+	Selected(selector Selector) []Member
+
+	/*
+	   SignalSelected sends a signal to every member matching the selector, as
+	   Signal would, and returns their names.
+	*/
+	SignalSelected(selector Selector, signal os.Signal) []string
+
+	/*
+	   SelectedEntranceListener is an EntranceListener which only receives the
+	   events of members matching the selector.
+	*/
+	SelectedEntranceListener(selector Selector) <-chan EntranceEvent
+
+	/*
+	   SelectedExitListener is an ExitListener which only receives the events of
+	   members matching the selector.
+	*/
+	SelectedExitListener(selector Selector) <-chan ExitEvent
 }
 
+/*
+A Shrink policy decides what happens to running members when a dynamic group's
+capacity is lowered below the number of members it is running.
+*/
+type Shrink int
+
+const (
+	// KeepMembers leaves running members alone; the group admits no new members
+	// until enough of them exit.
+	KeepMembers Shrink = iota
+
+	// StopNewest stops the most recently inserted members.
+	StopNewest
+
+	// StopOldest stops the earliest inserted members.
+	StopOldest
+)
+
+/*
+ErrMemberNotFound is returned when a request names a member which is not
+running in the group.
+*/
+type ErrMemberNotFound struct {
+	Name string
+}
+
+func (e ErrMemberNotFound) Error() string {
+	return fmt.Sprintf("member %s not found", e.Name)
+}
+
+// ErrGroupClosed is returned when a closed dynamic group is asked to start a
+// new process.
+var ErrGroupClosed = errors.New("group is closed")
+
+// ErrGroupFull is returned by TryInsert when a dynamic group is at capacity.
+var ErrGroupFull = errors.New("group is full")
+
+// ErrGroupNotRunning is returned by TryInsert when a dynamic group has not
+// started running.
+var ErrGroupNotRunning = errors.New("group is not running")
+
+// ErrMemberRemoved is the cause given to a member stopped by Remove.
+var ErrMemberRemoved = errors.New("member removed from group")
+
+// ErrMemberReplaced is the cause given to a member's old process once its
+// replacement is ready.
+var ErrMemberReplaced = errors.New("member replaced")
+
+// ErrCapacityReduced is the cause given to a member stopped by SetCapacity.
+var ErrCapacityReduced = errors.New("group capacity reduced")
+
 type memberRequest struct {
 	Name     string
-	Response chan ifrit.Process
+	Response chan runningMember
+}
+
+type membersRequest struct {
+	Response chan []MemberStatus
+}
+
+type insertRequest struct {
+	Member   Member
+	Response chan error
+}
+
+type signalRequest struct {
+	Name     string
+	Signal   os.Signal
+	Response chan error
+}
+
+type removeRequest struct {
+	Name     string
+	Response chan error
+}
+
+type replaceRequest struct {
+	Name     string
+	Runner   ifrit.Runner
+	Response chan error
+}
+
+// A selectRequest lists the members matching a selector, and signals them if
+// Signal is set.
+type selectRequest struct {
+	Selector Selector
+	Signal   os.Signal
+	Response chan []runningMember
+}
+
+type capacityRequest struct {
+	Capacity int
+	Shrink   Shrink
+	Response chan struct{}
 }
 
 /*
 dynamicClient implements DynamicClient.
 */
 type dynamicClient struct {
-	insertChannel       chan Member
-	getMemberChannel    chan memberRequest
-	completeNotifier    chan struct{}
-	closeNotifier       chan struct{}
-	closeOnce           *sync.Once
-	entranceBroadcaster *entranceEventBroadcaster
-	exitBroadcaster     *exitEventBroadcaster
+	insertChannel        chan Member
+	insertRequestChannel chan insertRequest
+	tryInsertChannel     chan insertRequest
+	getMemberChannel     chan memberRequest
+	membersChannel       chan membersRequest
+	signalChannel        chan signalRequest
+	removeChannel        chan removeRequest
+	replaceChannel       chan replaceRequest
+	capacityChannel      chan capacityRequest
+	selectChannel        chan selectRequest
+	runNotifier          chan struct{}
+	completeNotifier     chan struct{}
+	closeNotifier        chan struct{}
+	closeOnce            *sync.Once
+	entranceBroadcaster  *entranceEventBroadcaster
+	exitBroadcaster      *exitEventBroadcaster
 }
 
 func newClient(bufferSize int) dynamicClient {
 	return dynamicClient{
-		insertChannel:       make(chan Member),
-		getMemberChannel:    make(chan memberRequest),
-		completeNotifier:    make(chan struct{}),
-		closeNotifier:       make(chan struct{}),
-		closeOnce:           new(sync.Once),
-		entranceBroadcaster: newEntranceEventBroadcaster(bufferSize),
-		exitBroadcaster:     newExitEventBroadcaster(bufferSize),
+		insertChannel:        make(chan Member),
+		insertRequestChannel: make(chan insertRequest),
+		tryInsertChannel:     make(chan insertRequest),
+		getMemberChannel:     make(chan memberRequest),
+		membersChannel:       make(chan membersRequest),
+		signalChannel:        make(chan signalRequest),
+		removeChannel:        make(chan removeRequest),
+		replaceChannel:       make(chan replaceRequest),
+		capacityChannel:      make(chan capacityRequest),
+		selectChannel:        make(chan selectRequest),
+		runNotifier:          make(chan struct{}),
+		completeNotifier:     make(chan struct{}),
+		closeNotifier:        make(chan struct{}),
+		closeOnce:            new(sync.Once),
+		entranceBroadcaster:  newEntranceEventBroadcaster(bufferSize),
+		exitBroadcaster:      newExitEventBroadcaster(bufferSize),
 	}
 }
 
 func (c dynamicClient) Get(name string) (ifrit.Process, bool) {
+	member, ok := c.lookup(name)
+	if ok {
+		return member.process, true
+	}
+	return getPath(c, name)
+}
+
+func (c dynamicClient) lookup(name string) (runningMember, bool) {
 	req := memberRequest{
 		Name:     name,
-		Response: make(chan ifrit.Process),
+		Response: make(chan runningMember),
 	}
 	select {
 	case c.getMemberChannel <- req:
-		p, ok := <-req.Response
-		if !ok {
-			return nil, false
-		}
-		return p, true
+		member, ok := <-req.Response
+		return member, ok
 	case <-c.completeNotifier:
-		return nil, false
+		return runningMember{}, false
 	}
 }
 
+func (c dynamicClient) runningMembers() []runningMember {
+	return c.selectMembers(nil, nil)
+}
+
+func (c dynamicClient) Tree() Tree {
+	return newTree(c)
+}
+
 func (c dynamicClient) memberRequests() chan memberRequest {
 	return c.getMemberChannel
 }
 
+func (c dynamicClient) Members() []MemberStatus {
+	req := membersRequest{
+		Response: make(chan []MemberStatus, 1),
+	}
+	select {
+	case c.membersChannel <- req:
+		return <-req.Response
+	case <-c.completeNotifier:
+		return nil
+	}
+}
+
+func (c dynamicClient) membersRequests() chan membersRequest {
+	return c.membersChannel
+}
+
+func (c dynamicClient) Signal(name string, signal os.Signal) error {
+	req := signalRequest{
+		Name:     name,
+		Signal:   signal,
+		Response: make(chan error, 1),
+	}
+	select {
+	case c.signalChannel <- req:
+		return <-req.Response
+	case <-c.completeNotifier:
+		return ErrMemberNotFound{name}
+	}
+}
+
+func (c dynamicClient) signalRequests() chan signalRequest {
+	return c.signalChannel
+}
+
+func (c dynamicClient) Remove(name string) error {
+	req := removeRequest{
+		Name:     name,
+		Response: make(chan error, 1),
+	}
+	select {
+	case c.removeChannel <- req:
+		return <-req.Response
+	case <-c.completeNotifier:
+		return ErrMemberNotFound{name}
+	}
+}
+
+func (c dynamicClient) removeRequests() chan removeRequest {
+	return c.removeChannel
+}
+
+func (c dynamicClient) Replace(name string, runner ifrit.Runner) error {
+	req := replaceRequest{
+		Name:     name,
+		Runner:   runner,
+		Response: make(chan error, 1),
+	}
+	select {
+	case c.replaceChannel <- req:
+		return <-req.Response
+	case <-c.completeNotifier:
+		return ErrMemberNotFound{name}
+	}
+}
+
+func (c dynamicClient) replaceRequests() chan replaceRequest {
+	return c.replaceChannel
+}
+
+func (c dynamicClient) SetCapacity(capacity int, shrink Shrink) error {
+	req := capacityRequest{
+		Capacity: capacity,
+		Shrink:   shrink,
+		Response: make(chan struct{}),
+	}
+	select {
+	case c.capacityChannel <- req:
+		<-req.Response
+		return nil
+	case <-c.completeNotifier:
+		return ErrGroupClosed
+	}
+}
+
+func (c dynamicClient) capacityRequests() chan capacityRequest {
+	return c.capacityChannel
+}
+
+func (c dynamicClient) Selected(selector Selector) []Member {
+	selected := c.selectMembers(selector, nil)
+	if selected == nil {
+		return nil
+	}
+
+	members := make([]Member, 0, len(selected))
+	for _, member := range selected {
+		members = append(members, member.Member)
+	}
+	return members
+}
+
+func (c dynamicClient) SignalSelected(selector Selector, signal os.Signal) []string {
+	names := []string{}
+	for _, member := range c.selectMembers(selector, signal) {
+		names = append(names, member.Name)
+	}
+	return names
+}
+
+func (c dynamicClient) selectMembers(selector Selector, signal os.Signal) []runningMember {
+	req := selectRequest{
+		Selector: selector,
+		Signal:   signal,
+		Response: make(chan []runningMember, 1),
+	}
+	select {
+	case c.selectChannel <- req:
+		return <-req.Response
+	case <-c.completeNotifier:
+		return nil
+	}
+}
+
+func (c dynamicClient) selectRequests() chan selectRequest {
+	return c.selectChannel
+}
+
 func (c dynamicClient) Inserter() chan<- Member {
 	return c.insertChannel
 }
@@ -111,6 +662,53 @@ func (c dynamicClient) insertEventListener() <-chan Member {
 	return c.insertChannel
 }
 
+func (c dynamicClient) Insert(ctx context.Context, member Member) error {
+	req := insertRequest{
+		Member:   member,
+		Response: make(chan error, 1),
+	}
+	select {
+	case c.insertRequestChannel <- req:
+		return <-req.Response
+	case <-c.closeNotifier:
+		return ErrGroupClosed
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (c dynamicClient) insertRequests() chan insertRequest {
+	return c.insertRequestChannel
+}
+
+func (c dynamicClient) TryInsert(member Member) error {
+	select {
+	case <-c.runNotifier:
+	default:
+		return ErrGroupNotRunning
+	}
+
+	req := insertRequest{
+		Member:   member,
+		Response: make(chan error, 1),
+	}
+	select {
+	case c.tryInsertChannel <- req:
+		return <-req.Response
+	case <-c.completeNotifier:
+		return ErrGroupClosed
+	}
+}
+
+func (c dynamicClient) tryInsertRequests() chan insertRequest {
+	return c.tryInsertChannel
+}
+
+// running announces that the group's run loop has started.
+func (c dynamicClient) running() {
+	close(c.runNotifier)
+}
+
 func (c dynamicClient) EntranceListener() <-chan EntranceEvent {
 	return c.entranceBroadcaster.Attach()
 }
@@ -127,6 +725,14 @@ func (c dynamicClient) ExitListener() <-chan ExitEvent {
 	return c.exitBroadcaster.Attach()
 }
 
+func (c dynamicClient) SelectedEntranceListener(selector Selector) <-chan EntranceEvent {
+	return selectEntrances(c.EntranceListener(), selector)
+}
+
+func (c dynamicClient) SelectedExitListener(selector Selector) <-chan ExitEvent {
+	return selectExits(c.ExitListener(), selector)
+}
+
 func (c dynamicClient) broadcastExit(event ExitEvent) {
 	c.exitBroadcaster.Broadcast(event)
 }
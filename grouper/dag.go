@@ -0,0 +1,206 @@
+package grouper
+
+import (
+	"os"
+)
+
+/*
+NewDAG starts each member as soon as every member it DependsOn is ready, so
+independent members start in parallel.  On shutdown, a member is only signaled
+once every member which depends on it has exited.  Use a DAG group to describe
+processes whose dependencies form a graph rather than a list.
+
+Dependencies are checked with Members.ValidateDependencies when the group is
+run.
+*/
+func NewDAG(terminationSignal os.Signal, members Members, opts ...Option) StaticGroup {
+	return &dagGroup{newStaticGroup(terminationSignal, members, opts)}
+}
+
+type dagGroup struct {
+	staticGroup
+}
+
+func (g *dagGroup) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	defer g.client.closeBroadcasters()
+
+	err := g.validate()
+	if err != nil {
+		return err
+	}
+	g.reset()
+
+	signal, errTrace := g.dagStart(signals)
+	if errTrace.stopsGroup() {
+		return g.stop(g.terminationSignal, shutdownCause(signals, errTrace), signals, errTrace).ErrorOrNil()
+	}
+
+	if signal != nil {
+		return g.stop(signal, shutdownCause(signals, errTrace), signals, errTrace).ErrorOrNil()
+	}
+
+	close(ready)
+
+	signal, errTrace = g.waitForSignal(signals, errTrace)
+	return g.stop(signal, shutdownCause(signals, errTrace), signals, errTrace).ErrorOrNil()
+}
+
+func (g *dagGroup) validate() error {
+	err := g.members.Validate()
+	if err != nil {
+		return err
+	}
+	return g.members.ValidateDependencies()
+}
+
+func (g *dagGroup) dagStart(signals <-chan os.Signal) (os.Signal, ErrorTrace) {
+	numMembers := len(g.members)
+	if numMembers == 0 {
+		return nil, nil
+	}
+
+	// waiting counts the dependencies of each member which are not yet ready.
+	waiting := make([]int, numMembers)
+	dependents := map[string][]int{}
+	for i, member := range g.members {
+		waiting[i] = len(member.DependsOn)
+		for _, dependency := range member.DependsOn {
+			dependents[dependency] = append(dependents[dependency], i)
+		}
+	}
+
+	numReady := 0
+	readyMembers := make([]bool, numMembers)
+
+	// markReady starts the members a ready member unblocks, and reports whether
+	// every member is ready.
+	markReady := func(i int) bool {
+		readyMembers[i] = true
+		numReady++
+		if numReady == numMembers {
+			return true
+		}
+		for _, j := range dependents[g.members[i].Name] {
+			waiting[j]--
+			if waiting[j] == 0 {
+				g.startMember(signals, j)
+			}
+		}
+		return false
+	}
+
+	for i := range g.members {
+		if waiting[i] == 0 {
+			g.startMember(signals, i)
+		}
+	}
+
+	var errTrace ErrorTrace
+	for {
+		event, signal := g.nextEvent(signals)
+		if signal != nil {
+			return signal, errTrace
+		}
+
+		if event.kind == eventReady {
+			g.memberReady(g.members[event.index])
+			if markReady(event.index) {
+				return nil, errTrace
+			}
+			continue
+		}
+
+		var exit ExitEvent
+		errTrace, exit = g.recordExit(errTrace, event)
+		if !exit.Member.tolerates(exit.Err) {
+			return nil, errTrace
+		}
+		if !readyMembers[event.index] && markReady(event.index) {
+			return nil, errTrace
+		}
+	}
+}
+
+func (g *dagGroup) stop(signal os.Signal, cause error, signals <-chan os.Signal, errTrace ErrorTrace) ErrorTrace {
+	deadline := g.shutdownDeadline()
+	errOccurred := false
+	for _, exitEvent := range errTrace {
+		if exitEvent.Err != nil {
+			errOccurred = true
+		}
+	}
+
+	// liveDependents counts the started members which depend on each member
+	// and have not yet exited.
+	indexes := map[string]int{}
+	liveDependents := map[string]int{}
+	for i, member := range g.members {
+		indexes[member.Name] = i
+		if g.isLive(member) {
+			for _, dependency := range member.DependsOn {
+				liveDependents[dependency]++
+			}
+		}
+	}
+
+	stopped := make(chan memberStop, len(g.members))
+	stopping := make([]bool, len(g.members))
+	numStopping := 0
+
+	// stopIfUnneeded stops a live member once nothing depends on it.
+	stopIfUnneeded := func(i int) {
+		member := g.members[i]
+		if !g.isLive(member) || stopping[i] || liveDependents[member.Name] > 0 {
+			return
+		}
+		stopping[i] = true
+		numStopping++
+		g.stopInBackground(i, signal, cause, deadline, stopped)
+	}
+
+	for i := len(g.members) - 1; i >= 0; i-- {
+		stopIfUnneeded(i)
+	}
+
+	for numStopping > 0 {
+		select {
+		case stop := <-stopped:
+			numStopping--
+			member := g.members[stop.index]
+			errTrace = g.memberExited(errTrace, newExitEvent(member, g.pool[member.Name], stop.err))
+			if stop.err != nil {
+				errOccurred = true
+			}
+
+			for _, dependency := range member.DependsOn {
+				liveDependents[dependency]--
+			}
+			for j := len(member.DependsOn) - 1; j >= 0; j-- {
+				stopIfUnneeded(indexes[member.DependsOn[j]])
+			}
+
+		case sig := <-signals:
+			if sig == signal {
+				continue
+			}
+			signal = sig
+			for i, member := range g.members {
+				if stopping[i] && !g.exited[member.Name] {
+					g.pool[member.Name].Signal(signal)
+				}
+			}
+		}
+	}
+
+	if errOccurred {
+		return errTrace
+	}
+
+	return nil
+}
+
+// isLive reports whether a member has started, and has not yet exited.
+func (g *dagGroup) isLive(member Member) bool {
+	_, started := g.pool[member.Name]
+	return started && !g.exited[member.Name]
+}
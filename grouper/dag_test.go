@@ -0,0 +1,184 @@
+package grouper_test
+
+import (
+	"errors"
+	"os"
+	"syscall"
+
+	"github.com/tedsuo/ifrit"
+	"github.com/tedsuo/ifrit/fake_runner"
+	"github.com/tedsuo/ifrit/ginkgomon"
+	"github.com/tedsuo/ifrit/grouper"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DAG Group", func() {
+	var (
+		groupRunner  ifrit.Runner
+		groupProcess ifrit.Process
+		members      grouper.Members
+
+		dbRunner     *fake_runner.TestRunner
+		cacheRunner  *fake_runner.TestRunner
+		apiRunner    *fake_runner.TestRunner
+		workerRunner *fake_runner.TestRunner
+	)
+
+	BeforeEach(func() {
+		dbRunner = fake_runner.NewTestRunner()
+		cacheRunner = fake_runner.NewTestRunner()
+		apiRunner = fake_runner.NewTestRunner()
+		workerRunner = fake_runner.NewTestRunner()
+
+		members = grouper.Members{
+			{Name: "worker", Runner: workerRunner, DependsOn: []string{"api"}},
+			{Name: "api", Runner: apiRunner, DependsOn: []string{"db", "cache"}},
+			{Name: "db", Runner: dbRunner},
+			{Name: "cache", Runner: cacheRunner},
+		}
+
+		groupRunner = grouper.NewDAG(os.Interrupt, members)
+	})
+
+	AfterEach(func() {
+		dbRunner.EnsureExit()
+		cacheRunner.EnsureExit()
+		apiRunner.EnsureExit()
+		workerRunner.EnsureExit()
+
+		ginkgomon.Kill(groupProcess)
+	})
+
+	Describe("Start", func() {
+		BeforeEach(func() {
+			groupProcess = ifrit.Background(groupRunner)
+		})
+
+		It("starts members without dependencies at the same time", func() {
+			Eventually(dbRunner.RunCallCount).Should(Equal(1))
+			Eventually(cacheRunner.RunCallCount).Should(Equal(1))
+			Consistently(apiRunner.RunCallCount).Should(Equal(0))
+		})
+
+		It("starts a member once all of its dependencies are ready", func() {
+			dbRunner.TriggerReady()
+			Consistently(apiRunner.RunCallCount).Should(Equal(0))
+
+			cacheRunner.TriggerReady()
+			Eventually(apiRunner.RunCallCount).Should(Equal(1))
+			Consistently(workerRunner.RunCallCount).Should(Equal(0))
+
+			apiRunner.TriggerReady()
+			Eventually(workerRunner.RunCallCount).Should(Equal(1))
+			Consistently(groupProcess.Ready()).ShouldNot(BeClosed())
+
+			workerRunner.TriggerReady()
+			Eventually(groupProcess.Ready()).Should(BeClosed())
+		})
+
+		Context("when a member exits before the group is ready", func() {
+			It("stops the started members and does not start the rest", func() {
+				dbSignals := dbRunner.WaitForCall()
+				dbRunner.TriggerReady()
+				cacheRunner.TriggerExit(errors.New("Fail"))
+
+				Eventually(dbSignals).Should(Receive(Equal(os.Interrupt)))
+				dbRunner.TriggerExit(nil)
+
+				var err error
+				Eventually(groupProcess.Wait()).Should(Receive(&err))
+				Ω(err).Should(ConsistOf(
+					matchExitEvent(members[3], errors.New("Fail")),
+					matchExitEvent(members[2], nil),
+				))
+				Ω(apiRunner.RunCallCount()).Should(Equal(0))
+			})
+		})
+	})
+
+	Describe("Stop", func() {
+		var dbSignals, cacheSignals, apiSignals, workerSignals <-chan os.Signal
+
+		BeforeEach(func() {
+			groupProcess = ifrit.Background(groupRunner)
+
+			dbSignals = dbRunner.WaitForCall()
+			dbRunner.TriggerReady()
+			cacheSignals = cacheRunner.WaitForCall()
+			cacheRunner.TriggerReady()
+			apiSignals = apiRunner.WaitForCall()
+			apiRunner.TriggerReady()
+			workerSignals = workerRunner.WaitForCall()
+			workerRunner.TriggerReady()
+
+			Eventually(groupProcess.Ready()).Should(BeClosed())
+			groupProcess.Signal(syscall.SIGTERM)
+		})
+
+		It("stops members in reverse dependency order", func() {
+			Eventually(workerSignals).Should(Receive(Equal(syscall.SIGTERM)))
+			Consistently(apiSignals).ShouldNot(Receive())
+
+			workerRunner.TriggerExit(nil)
+			Eventually(apiSignals).Should(Receive(Equal(syscall.SIGTERM)))
+			Consistently(dbSignals).ShouldNot(Receive())
+			Consistently(cacheSignals).ShouldNot(Receive())
+
+			apiRunner.TriggerExit(nil)
+			Eventually(dbSignals).Should(Receive(Equal(syscall.SIGTERM)))
+			Eventually(cacheSignals).Should(Receive(Equal(syscall.SIGTERM)))
+
+			cacheRunner.TriggerExit(nil)
+			dbRunner.TriggerExit(nil)
+			Eventually(groupProcess.Wait()).Should(Receive(BeNil()))
+		})
+
+		It("records the exits in the order they happened", func() {
+			Eventually(workerSignals).Should(Receive())
+			workerRunner.TriggerExit(nil)
+			Eventually(apiSignals).Should(Receive())
+			apiRunner.TriggerExit(errors.New("Fail"))
+			Eventually(cacheSignals).Should(Receive())
+			cacheRunner.TriggerExit(nil)
+			Eventually(dbSignals).Should(Receive())
+			dbRunner.TriggerExit(nil)
+
+			var err error
+			Eventually(groupProcess.Wait()).Should(Receive(&err))
+			errTrace := err.(grouper.ErrorTrace)
+			Ω(errTrace).Should(HaveLen(4))
+			Ω(errTrace[0]).Should(matchExitEvent(members[0], nil))
+			Ω(errTrace[1]).Should(matchExitEvent(members[1], errors.New("Fail")))
+			Ω(errTrace[2:]).Should(ConsistOf(
+				matchExitEvent(members[2], nil),
+				matchExitEvent(members[3], nil),
+			))
+		})
+	})
+
+	Describe("Validation", func() {
+		It("fails to run with an unknown dependency", func() {
+			groupRunner = grouper.NewDAG(os.Interrupt, grouper.Members{
+				{Name: "api", Runner: apiRunner, DependsOn: []string{"db"}},
+			})
+			groupProcess = ifrit.Background(groupRunner)
+
+			Eventually(groupProcess.Wait()).Should(Receive(Equal(grouper.ErrUnknownDependency{Member: "api", Dependency: "db"})))
+			Ω(apiRunner.RunCallCount()).Should(Equal(0))
+		})
+
+		It("fails to run with a dependency cycle", func() {
+			groupRunner = grouper.NewDAG(os.Interrupt, grouper.Members{
+				{Name: "api", Runner: apiRunner, DependsOn: []string{"db"}},
+				{Name: "db", Runner: dbRunner, DependsOn: []string{"api"}},
+			})
+			groupProcess = ifrit.Background(groupRunner)
+
+			var err error
+			Eventually(groupProcess.Wait()).Should(Receive(&err))
+			Ω(err).Should(BeAssignableToTypeOf(grouper.ErrDependencyCycle{}))
+		})
+	})
+})
@@ -4,12 +4,29 @@ which are then organized into an execution tree.  If you have modeled your subsy
 as ifrit runners, startup and shutdown of your entire application can now
 be controlled.
 
-Grouper provides three strategies for system startup: two static group
-strategies, and one DynamicGroup.  Each static group strategy takes a
+Grouper provides several strategies for system startup: static group
+strategies, and the DynamicGroup.  Each static group strategy takes a
 list of members, and starts the members in the following manner:
 
   - Parallel: all processes are started simultaneously.
   - Ordered:  the next process is started when the previous is ready.
+  - DAG:      each process is started when the processes it DependsOn are ready.
+  - Staged:   each stage is started in parallel when the previous stage is ready.
+  - Quorum:   all processes are started simultaneously; the group is ready
+    once a quorum of them are ready.
+  - HotStandby: a primary process is started, and a standby is promoted when
+    the primary fails.
+
+Each static group provides a StaticClient, which emits entrance and exit events
+as members become ready and exit, and gets running members by name.
+
+Members can be given Labels.  Both static and dynamic clients can list and
+signal the members matching a Selector, and filter their events by Selector,
+for example to drain ingress members before stopping the rest of a group.
+
+Clients follow paths through nested groups: Get("services/api/http") finds the
+http member of the api group, within the services group.  Tree dumps every
+running member of a group and its nested groups, along with their states.
 
 The DynamicGroup allows up to N processes to be run concurrently. The dynamic
 group runs indefinitely until it is closed or signaled. The DynamicGroup provides
@@ -20,6 +37,10 @@ following properties:
   - A dynamic group can be manually closed via it's client.
   - A dynamic group is automatically closed once it is signaled.
   - Once a dynamic group is closed, it acts like a static group.
+  - Individual members can be signaled, removed, or replaced via the client,
+    without stopping the rest of the group.
+  - A dynamic group's capacity can be changed while it runs via the client.
+  - The client can list a snapshot of every member and its MemberState.
 
 Groups can optionally be configured with a termination signal, and all groups
 have the same signaling and shutdown properties:
@@ -27,5 +48,21 @@ have the same signaling and shutdown properties:
   - The group propogates all received signals to all running members.
   - If a member exits before being signaled, the group propogates the
     termination signal.  A nil termination signal is not propogated.
+  - In static groups, a member's Policy can allow it to exit without stopping
+    the group: Optional members may exit at any time, and OneShot members may
+    exit successfully.  Quorum groups instead keep running while a quorum of
+    members is running.
+  - If a member has a ReadyTimeout and does not become ready in time, it is
+    stopped and treated as having exited with an ErrReadyTimeout.
+
+Static groups can be configured WithEscalation and WithShutdownBudget, so that
+shutdown does not hang on a member which ignores its signal.  Members can
+override the group's signal, escalation and timeout with StopSignal,
+Escalation and StopTimeout.  Parallel groups can be configured
+WithStartupWindow, so that large groups start a few members at a time.
+
+When a group exits with an error, it returns an ErrorTrace recording how and
+when each member exited.  Traces of nested groups can be flattened into member
+paths such as "api/http", searched with errors.Is, and rendered as JSON.
 */
 package grouper
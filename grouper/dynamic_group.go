@@ -1,8 +1,8 @@
 package grouper
 
 import (
-	"fmt"
 	"os"
+	"time"
 
 	"github.com/tedsuo/ifrit"
 )
@@ -28,7 +28,8 @@ type dynamicGroup struct {
 /*
 NewDynamic creates a DynamicGroup.
 
-The maxCapacity argument sets the maximum number of concurrent processes.
+The maxCapacity argument sets the maximum number of concurrent processes.  It
+can be changed later with DynamicClient.SetCapacity.
 
 The eventBufferSize argument sets the number of entrance and exit events to be
 retained by the system.  When a new event listener attaches, it will receive
@@ -55,24 +56,62 @@ func (p *dynamicGroup) Client() DynamicClient {
 func (p *dynamicGroup) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
 	processes := newProcessSet()
 	insertEvents := p.client.insertEventListener()
+	insertRequests := p.client.insertRequests()
+	tryInsertRequests := p.client.tryInsertRequests()
 	memberRequests := p.client.memberRequests()
+	signalRequests := p.client.signalRequests()
+	removeRequests := p.client.removeRequests()
+	replaceRequests := p.client.replaceRequests()
+	capacityRequests := p.client.capacityRequests()
+	membersRequests := p.client.membersRequests()
+	selectRequests := p.client.selectRequests()
 	closeNotifier := p.client.CloseNotifier()
 	entranceEvents := make(entranceEventChannel)
-	exitEvents := make(exitEventChannel)
+	exitEvents := make(chan memberExit)
 
+	capacity := p.poolSize
 	invoking := 0
+	p.client.running()
 	close(ready)
 
+	// admitInserts opens the insert channels while the group is open and has
+	// room, and closes them otherwise.
+	admitInserts := func() {
+		if closeNotifier != nil && !processes.Signaled() && processes.Length() < capacity {
+			insertEvents = p.client.insertEventListener()
+			insertRequests = p.client.insertRequests()
+		} else {
+			insertEvents = nil
+			insertRequests = nil
+		}
+	}
+
+	insert := func(member Member) error {
+		if processes.Contains(member.Name) {
+			return ErrDuplicateName{member.Name}
+		}
+
+		process := member.start(signals)
+		processes.Add(member, process)
+		admitInserts()
+
+		invoking++
+
+		go waitForEvents(member, process, processes.Stopping(process), p.terminationSignal, entranceEvents, exitEvents)
+		return nil
+	}
+
 	for {
 		select {
 		case shutdown := <-signals:
-			processes.Signal(shutdown)
+			processes.Signal(shutdown, ifrit.Cause(signals))
 			p.client.Close()
 
 		case <-closeNotifier:
 			closeNotifier = nil
 			insertEvents = nil
-			if processes.Length() == 0 {
+			insertRequests = nil
+			if processes.Running() == 0 {
 				return p.client.closeBroadcasters()
 			}
 			if invoking == 0 {
@@ -82,64 +121,164 @@ func (p *dynamicGroup) Run(signals <-chan os.Signal, ready chan<- struct{}) erro
 		case memberRequest := <-memberRequests:
 			p, ok := processes.Get(memberRequest.Name)
 			if ok {
-				memberRequest.Response <- p
+				memberRequest.Response <- runningMember{Member: processes.Member(memberRequest.Name), process: p}
 			}
 			close(memberRequest.Response)
 
+		case membersRequest := <-membersRequests:
+			membersRequest.Response <- processes.Snapshot()
+
+		case selectRequest := <-selectRequests:
+			members := processes.Select(selectRequest.Selector)
+			if selectRequest.Signal != nil {
+				for _, member := range members {
+					member.process.Signal(selectRequest.Signal)
+				}
+			}
+			selectRequest.Response <- members
+
+		case signalRequest := <-signalRequests:
+			process, ok := processes.Get(signalRequest.Name)
+			if !ok {
+				signalRequest.Response <- ErrMemberNotFound{signalRequest.Name}
+				break
+			}
+			process.Signal(signalRequest.Signal)
+			signalRequest.Response <- nil
+
+		case removeRequest := <-removeRequests:
+			process, ok := processes.Get(removeRequest.Name)
+			if !ok {
+				removeRequest.Response <- ErrMemberNotFound{removeRequest.Name}
+				break
+			}
+			processes.Detach(process, func(err error) {
+				removeRequest.Response <- err
+			})
+			ifrit.SignalWithCause(process, p.stopSignal(processes.Member(removeRequest.Name)), ErrMemberRemoved)
+
+		case replaceRequest := <-replaceRequests:
+			if closeNotifier == nil {
+				replaceRequest.Response <- ErrGroupClosed
+				break
+			}
+			if _, ok := processes.Get(replaceRequest.Name); !ok {
+				replaceRequest.Response <- ErrMemberNotFound{replaceRequest.Name}
+				break
+			}
+
+			member := processes.Member(replaceRequest.Name)
+			member.Runner = replaceRequest.Runner
+			process := member.start(signals)
+			processes.AddReplacement(member, process, replaceRequest.Response)
+
+			invoking++
+
+			go waitForEvents(member, process, processes.Stopping(process), p.terminationSignal, entranceEvents, exitEvents)
+
 		case newMember, ok := <-insertEvents:
 			if !ok {
 				p.client.Close()
 				insertEvents = nil
+				insertRequests = nil
 				break
 			}
 
-			process := ifrit.Background(newMember)
-			processes.Add(newMember.Name, process)
-
-			if processes.Length() == p.poolSize {
-				insertEvents = nil
+			err := insert(newMember)
+			if err != nil {
+				p.client.broadcastExit(ExitEvent{Member: newMember, Err: err})
 			}
 
-			invoking++
+		case insertRequest := <-insertRequests:
+			insertRequest.Response <- insert(insertRequest.Member)
+
+		case insertRequest := <-tryInsertRequests:
+			switch {
+			case closeNotifier == nil || processes.Signaled():
+				insertRequest.Response <- ErrGroupClosed
+			case processes.Length() >= capacity:
+				insertRequest.Response <- ErrGroupFull
+			default:
+				insertRequest.Response <- insert(insertRequest.Member)
+			}
 
-			go waitForEvents(newMember, process, entranceEvents, exitEvents)
+		case capacityRequest := <-capacityRequests:
+			capacity = capacityRequest.Capacity
+			if capacity < 0 {
+				capacity = 0
+			}
+			for _, name := range processes.Excess(capacity, capacityRequest.Shrink) {
+				process, _ := processes.Get(name)
+				processes.Detach(process, func(error) {})
+				ifrit.SignalWithCause(process, p.stopSignal(processes.Member(name)), ErrCapacityReduced)
+			}
+			admitInserts()
+			close(capacityRequest.Response)
 
 		case entranceEvent := <-entranceEvents:
 			invoking--
 			p.client.broadcastEntrance(entranceEvent)
 
+			if replaced, ok := processes.CompleteReplacement(entranceEvent.Process); ok {
+				ifrit.SignalWithCause(replaced, p.stopSignal(entranceEvent.Member), ErrMemberReplaced)
+			}
+
 			if closeNotifier == nil && invoking == 0 {
 				p.client.closeEntranceBroadcaster()
 				entranceEvents = nil
 			}
 
-		case exitEvent := <-exitEvents:
-			processes.Remove(exitEvent.Member.Name)
-			p.client.broadcastExit(exitEvent)
+		case exit := <-exitEvents:
+			detached := processes.Remove(exit.process, exit.Err)
+			p.client.broadcastExit(exit.ExitEvent)
 
-			if !processes.Signaled() && p.terminationSignal != nil {
-				processes.Signal(p.terminationSignal)
+			if !detached && !processes.Signaled() && p.terminationSignal != nil {
+				processes.Signal(p.terminationSignal, ErrMemberExited{Name: exit.Member.Name, Err: exit.Err})
 				p.client.Close()
-				insertEvents = nil
 			}
 
-			if processes.Complete() || (processes.Length() == 0 && insertEvents == nil) {
+			if processes.Complete() || (processes.Running() == 0 && closeNotifier == nil) {
 				return p.client.closeBroadcasters()
 			}
 
-			if !processes.Signaled() && closeNotifier != nil {
-				insertEvents = p.client.insertEventListener()
-			}
+			admitInserts()
 		}
 	}
 }
 
+// stopSignal is the signal sent to a member which is removed or replaced.
+func (p *dynamicGroup) stopSignal(member Member) os.Signal {
+	switch {
+	case member.StopSignal != nil:
+		return member.StopSignal
+	case p.terminationSignal != nil:
+		return p.terminationSignal
+	default:
+		return os.Interrupt
+	}
+}
+
+// memberExit is an ExitEvent along with the process which exited, since a
+// member may briefly have two processes while it is being replaced.
+type memberExit struct {
+	ExitEvent
+	process ifrit.Process
+}
+
+// waitForEvents reports a process's entrance and exit.  A process which does
+// not become ready within its ReadyTimeout is stopped, unless the group has
+// already begun stopping it.
 func waitForEvents(
 	member Member,
 	process ifrit.Process,
+	stopping <-chan struct{},
+	terminationSignal os.Signal,
 	entrance entranceEventChannel,
-	exit exitEventChannel,
+	exit chan<- memberExit,
 ) {
+	timer := startReadyTimer(member)
+	defer timer.Stop()
+
 	select {
 	case <-process.Ready():
 		entrance <- EntranceEvent{
@@ -147,32 +286,69 @@ func waitForEvents(
 			Process: process,
 		}
 
-		exit <- ExitEvent{
-			Member: member,
-			Err:    <-process.Wait(),
+		<-process.Done()
+		exit <- memberExit{newExitEvent(member, process, process.Err()), process}
+
+	case <-process.Done():
+		entrance <- EntranceEvent{
+			Member:  member,
+			Process: process,
 		}
 
-	case err := <-process.Wait():
+		exit <- memberExit{newExitEvent(member, process, process.Err()), process}
+
+	case <-stopping:
+		<-process.Done()
 		entrance <- EntranceEvent{
 			Member:  member,
 			Process: process,
 		}
 
-		exit <- ExitEvent{
-			Member: member,
-			Err:    err,
+		exit <- memberExit{newExitEvent(member, process, process.Err()), process}
+
+	case <-timer.C():
+		exitEvent := stopUnready(member, process, terminationSignal)
+
+		entrance <- EntranceEvent{
+			Member:  member,
+			Process: process,
 		}
+
+		exit <- memberExit{exitEvent, process}
 	}
 }
 
+// processSet tracks the processes of a dynamic group.  Each member has a
+// current process; while a member is being replaced, its replacement also
+// runs.  Detached processes are leaving the group, and their exit does not
+// stop it.  A process's stopping channel is closed once the group sends it a
+// stop signal, by detaching it or signaling the whole set.
 type processSet struct {
-	processes map[string]ifrit.Process
-	shutdown  os.Signal
+	processes    map[string]ifrit.Process
+	members      map[string]Member
+	order        []string
+	insertedAt   map[string]time.Time
+	running      map[ifrit.Process]struct{}
+	stopping     map[ifrit.Process]chan struct{}
+	detached     map[ifrit.Process][]func(error)
+	replacements map[ifrit.Process]replacement
+	shutdown     os.Signal
+}
+
+type replacement struct {
+	member   Member
+	response chan<- error
 }
 
 func newProcessSet() *processSet {
 	return &processSet{
-		processes: map[string]ifrit.Process{},
+		processes:    map[string]ifrit.Process{},
+		members:      map[string]Member{},
+		insertedAt:   map[string]time.Time{},
+		running:      map[ifrit.Process]struct{}{},
+		stopping:     map[ifrit.Process]chan struct{}{},
+		detached:     map[ifrit.Process][]func(error){},
+		replacements: map[ifrit.Process]replacement{},
 	}
 }
 
@@ -180,20 +356,41 @@ func (g *processSet) Signaled() bool {
 	return g.shutdown != nil
 }
 
-func (g *processSet) Signal(signal os.Signal) {
+func (g *processSet) Signal(signal os.Signal, cause error) {
 	g.shutdown = signal
 
-	for _, p := range g.processes {
-		p.Signal(signal)
+	for p := range g.running {
+		g.markStopping(p)
+		ifrit.SignalWithCause(p, signal, cause)
 	}
 }
 
+// Stopping returns a channel which is closed once the group begins stopping a
+// process.
+func (g *processSet) Stopping(process ifrit.Process) <-chan struct{} {
+	return g.stopping[process]
+}
+
+func (g *processSet) markStopping(process ifrit.Process) {
+	if stopping, ok := g.stopping[process]; ok {
+		close(stopping)
+		delete(g.stopping, process)
+	}
+}
+
+// Length is the number of members in the set.
 func (g *processSet) Length() int {
 	return len(g.processes)
 }
 
+// Running is the number of processes which have not exited, including
+// replacements and detached processes.
+func (g *processSet) Running() int {
+	return len(g.running)
+}
+
 func (g *processSet) Complete() bool {
-	return len(g.processes) == 0 && g.shutdown != nil
+	return len(g.running) == 0 && g.shutdown != nil
 }
 
 func (g *processSet) Get(name string) (ifrit.Process, bool) {
@@ -201,14 +398,151 @@ func (g *processSet) Get(name string) (ifrit.Process, bool) {
 	return p, ok
 }
 
-func (g *processSet) Add(name string, process ifrit.Process) {
+func (g *processSet) Member(name string) Member {
+	return g.members[name]
+}
+
+func (g *processSet) Contains(name string) bool {
 	_, ok := g.processes[name]
-	if ok {
-		panic(fmt.Errorf("member inserted twice: %#v", name))
+	return ok
+}
+
+func (g *processSet) Add(member Member, process ifrit.Process) {
+	g.processes[member.Name] = process
+	g.members[member.Name] = member
+	g.order = append(g.order, member.Name)
+	g.insertedAt[member.Name] = time.Now()
+	g.running[process] = struct{}{}
+	g.stopping[process] = make(chan struct{})
+}
+
+// AddReplacement tracks a process which will replace the member's current
+// process once it is ready.  The response is sent once the replacement is
+// complete, or has failed.
+func (g *processSet) AddReplacement(member Member, process ifrit.Process, response chan<- error) {
+	g.running[process] = struct{}{}
+	g.stopping[process] = make(chan struct{})
+	g.replacements[process] = replacement{member: member, response: response}
+}
+
+// CompleteReplacement is called once a replacement process has either become
+// ready or exited.  If it is ready, it becomes the member's current process,
+// and the process it replaced is detached and returned so it can be stopped.
+func (g *processSet) CompleteReplacement(process ifrit.Process) (ifrit.Process, bool) {
+	r, ok := g.replacements[process]
+	if !ok {
+		return nil, false
+	}
+	delete(g.replacements, process)
+
+	select {
+	case <-process.Ready():
+	default:
+		g.Detach(process, func(err error) {
+			r.response <- ErrMemberExited{Name: r.member.Name, Err: err}
+		})
+		return nil, false
+	}
+
+	replaced, ok := g.processes[r.member.Name]
+	g.processes[r.member.Name] = process
+	g.members[r.member.Name] = r.member
+
+	if !ok {
+		// The process it replaced has already exited, and the member was
+		// forgotten along with it.
+		g.order = append(g.order, r.member.Name)
+		g.insertedAt[r.member.Name] = time.Now()
+		r.response <- nil
+		return nil, false
+	}
+
+	g.Detach(replaced, func(error) {
+		r.response <- nil
+	})
+	return replaced, true
+}
+
+// Detach marks a process as leaving the group.  Its exit will not stop the
+// group, and will be passed to onExit.  The caller is expected to stop it.
+func (g *processSet) Detach(process ifrit.Process, onExit func(error)) {
+	g.markStopping(process)
+	g.detached[process] = append(g.detached[process], onExit)
+}
+
+// Remove forgets a process which has exited, and reports whether it was
+// detached.
+func (g *processSet) Remove(process ifrit.Process, err error) bool {
+	delete(g.running, process)
+	delete(g.stopping, process)
+	for name, p := range g.processes {
+		if p == process {
+			delete(g.processes, name)
+			delete(g.members, name)
+			delete(g.insertedAt, name)
+			g.forget(name)
+		}
+	}
+
+	onExits, detached := g.detached[process]
+	delete(g.detached, process)
+	for _, onExit := range onExits {
+		onExit(err)
+	}
+	return detached
+}
+
+func (g *processSet) forget(name string) {
+	for i, n := range g.order {
+		if n == name {
+			g.order = append(g.order[:i], g.order[i+1:]...)
+			return
+		}
 	}
-	g.processes[name] = process
 }
 
-func (g *processSet) Remove(name string) {
-	delete(g.processes, name)
+// Snapshot returns the status of every member, in insertion order.
+func (g *processSet) Snapshot() []MemberStatus {
+	statuses := make([]MemberStatus, 0, len(g.order))
+	for _, name := range g.order {
+		statuses = append(statuses, newMemberStatus(g.members[name], g.processes[name], g.insertedAt[name]))
+	}
+	return statuses
+}
+
+// Select returns the members matching the selector, in insertion order.
+func (g *processSet) Select(selector Selector) []runningMember {
+	members := []runningMember{}
+	for _, name := range g.order {
+		if member := g.members[name]; selector.Matches(member.Labels) {
+			members = append(members, runningMember{Member: member, process: g.processes[name]})
+		}
+	}
+	return members
+}
+
+// Excess returns the members which must be stopped for the set to fit within
+// capacity, ignoring members which are already leaving.  Members are chosen
+// by insertion order according to shrink.
+func (g *processSet) Excess(capacity int, shrink Shrink) []string {
+	var staying []string
+	for _, name := range g.order {
+		if _, leaving := g.detached[g.processes[name]]; !leaving {
+			staying = append(staying, name)
+		}
+	}
+
+	excess := len(staying) - capacity
+	if excess <= 0 {
+		return nil
+	}
+
+	switch shrink {
+	case StopOldest:
+		return staying[:excess]
+	case StopNewest:
+		return staying[len(staying)-excess:]
+	default:
+		return nil
+	}
 }
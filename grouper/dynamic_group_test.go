@@ -1,6 +1,8 @@
 package grouper_test
 
 import (
+	"context"
+	"errors"
 	"os"
 	"syscall"
 	"time"
@@ -38,9 +40,9 @@ var _ = Describe("dynamicGroup", func() {
 		var member1, member2, member3 grouper.Member
 
 		BeforeEach(func() {
-			member1 = grouper.Member{"child1", childRunner1}
-			member2 = grouper.Member{"child2", childRunner2}
-			member3 = grouper.Member{"child3", childRunner3}
+			member1 = grouper.Member{Name: "child1", Runner: childRunner1}
+			member2 = grouper.Member{Name: "child2", Runner: childRunner2}
+			member3 = grouper.Member{Name: "child3", Runner: childRunner3}
 
 			pool = grouper.NewDynamic(nil, 3, 2)
 			client = pool.Client()
@@ -66,13 +68,134 @@ var _ = Describe("dynamicGroup", func() {
 		})
 	})
 
+	Describe("Members", func() {
+		var member1, member2 grouper.Member
+
+		BeforeEach(func() {
+			member1 = grouper.Member{Name: "child1", Runner: childRunner1}
+			member2 = grouper.Member{Name: "child2", Runner: childRunner2}
+
+			pool = grouper.NewDynamic(nil, 3, 2)
+			client = pool.Client()
+			poolProcess = ifrit.Invoke(pool)
+
+			Ω(client.TryInsert(member1)).Should(Succeed())
+			Ω(client.TryInsert(member2)).Should(Succeed())
+		})
+
+		AfterEach(func() {
+			poolProcess.Signal(os.Kill)
+			Eventually(func() <-chan struct{} {
+				childRunner1.EnsureExit()
+				childRunner2.EnsureExit()
+				return poolProcess.Done()
+			}).Should(BeClosed())
+		})
+
+		It("lists every member in insertion order", func() {
+			members := client.Members()
+			Ω(members).Should(HaveLen(2))
+			Ω(members[0].Member.Name).Should(Equal("child1"))
+			Ω(members[1].Member.Name).Should(Equal("child2"))
+			Ω(members[0].InsertedAt).ShouldNot(BeZero())
+			Ω(members[0].InsertedAt).ShouldNot(BeTemporally(">", members[1].InsertedAt))
+		})
+
+		It("reports the state of each member", func() {
+			Eventually(childRunner1.RunCallCount).Should(Equal(1))
+			Eventually(childRunner2.RunCallCount).Should(Equal(1))
+			Ω(client.Members()[0].State).Should(Equal(grouper.Invoking))
+			Ω(client.Members()[0].ReadyAt).Should(BeZero())
+
+			childRunner1.TriggerReady()
+			Eventually(func() grouper.MemberState {
+				return client.Members()[0].State
+			}).Should(Equal(grouper.Ready))
+			Ω(client.Members()[0].ReadyAt).ShouldNot(BeZero())
+
+			Ω(client.Signal("child2", syscall.SIGUSR2)).Should(Succeed())
+			Ω(client.Members()[1].State).Should(Equal(grouper.Signaled))
+		})
+
+		It("drops members once they exit", func() {
+			childRunner1.TriggerExit(nil)
+			Eventually(client.Members).Should(HaveLen(1))
+			Ω(client.Members()[0].Member.Name).Should(Equal("child2"))
+		})
+
+		It("returns nil once the group has exited", func() {
+			client.Close()
+			childRunner1.TriggerExit(nil)
+			childRunner2.TriggerExit(nil)
+			Eventually(poolProcess.Wait()).Should(Receive())
+			Ω(client.Members()).Should(BeNil())
+		})
+	})
+
+	Describe("selecting members by label", func() {
+		var (
+			member1, member2   grouper.Member
+			signals1, signals2 <-chan os.Signal
+		)
+
+		BeforeEach(func() {
+			member1 = grouper.Member{Name: "child1", Runner: childRunner1, Labels: grouper.Labels{"tier": "ingress"}}
+			member2 = grouper.Member{Name: "child2", Runner: childRunner2, Labels: grouper.Labels{"tier": "backend"}}
+
+			pool = grouper.NewDynamic(nil, 3, 10)
+			client = pool.Client()
+			poolProcess = ifrit.Invoke(pool)
+
+			Ω(client.TryInsert(member1)).Should(Succeed())
+			signals1 = childRunner1.WaitForCall()
+			Ω(client.TryInsert(member2)).Should(Succeed())
+			signals2 = childRunner2.WaitForCall()
+		})
+
+		AfterEach(func() {
+			poolProcess.Signal(os.Kill)
+			Eventually(func() <-chan struct{} {
+				childRunner1.EnsureExit()
+				childRunner2.EnsureExit()
+				return poolProcess.Done()
+			}).Should(BeClosed())
+		})
+
+		It("lists the members matching a selector", func() {
+			Ω(client.Selected(grouper.Selector{"tier": "backend"})).Should(Equal([]grouper.Member{member2}))
+			Ω(client.Selected(nil)).Should(Equal([]grouper.Member{member1, member2}))
+		})
+
+		It("signals only the matching members", func() {
+			Ω(client.SignalSelected(grouper.Selector{"tier": "ingress"}, syscall.SIGUSR2)).Should(Equal([]string{"child1"}))
+			Eventually(signals1).Should(Receive(Equal(syscall.SIGUSR2)))
+			Consistently(signals2).ShouldNot(Receive())
+		})
+
+		It("filters events by selector", func() {
+			exits := client.SelectedExitListener(grouper.Selector{"tier": "ingress"})
+			entrances := client.SelectedEntranceListener(grouper.Selector{"tier": "ingress"})
+
+			childRunner2.TriggerReady()
+			childRunner1.TriggerReady()
+			Eventually(entrances).Should(Receive(WithTransform(func(e grouper.EntranceEvent) string {
+				return e.Member.Name
+			}, Equal("child1"))))
+
+			childRunner2.TriggerExit(nil)
+			childRunner1.TriggerExit(nil)
+			Eventually(exits).Should(Receive(matchExitEvent(member1, nil)))
+			Consistently(exits).ShouldNot(Receive())
+		})
+	})
+
 	Describe("Insert", func() {
 		var member1, member2, member3 grouper.Member
 
 		BeforeEach(func() {
-			member1 = grouper.Member{"child1", childRunner1}
-			member2 = grouper.Member{"child2", childRunner2}
-			member3 = grouper.Member{"child3", childRunner3}
+			member1 = grouper.Member{Name: "child1", Runner: childRunner1}
+			member2 = grouper.Member{Name: "child2", Runner: childRunner2}
+			member3 = grouper.Member{Name: "child3", Runner: childRunner3}
 
 			pool = grouper.NewDynamic(nil, 3, 2)
 			client = pool.Client()
@@ -146,4 +269,406 @@ var _ = Describe("dynamicGroup", func() {
 			Consistently(exits).ShouldNot(Receive())
 		})
 	})
+	Describe("managing individual members", func() {
+		var member1, member2 grouper.Member
+		var signal1, signal2 <-chan os.Signal
+
+		BeforeEach(func() {
+			member1 = grouper.Member{Name: "child1", Runner: childRunner1}
+			member2 = grouper.Member{Name: "child2", Runner: childRunner2}
+
+			pool = grouper.NewDynamic(os.Interrupt, 2, 10)
+			client = pool.Client()
+			poolProcess = ifrit.Invoke(pool)
+
+			insert := client.Inserter()
+			Eventually(insert).Should(BeSent(member1))
+			Eventually(insert).Should(BeSent(member2))
+
+			signal1 = childRunner1.WaitForCall()
+			childRunner1.TriggerReady()
+			signal2 = childRunner2.WaitForCall()
+			childRunner2.TriggerReady()
+		})
+
+		AfterEach(func() {
+			poolProcess.Signal(os.Kill)
+			Eventually(func() <-chan struct{} {
+				childRunner1.EnsureExit()
+				childRunner2.EnsureExit()
+				childRunner3.EnsureExit()
+				return poolProcess.Done()
+			}).Should(BeClosed())
+		})
+
+		Describe("Signal", func() {
+			It("signals only the named member", func() {
+				Ω(client.Signal("child1", syscall.SIGUSR2)).Should(Succeed())
+				Eventually(signal1).Should(Receive(Equal(syscall.SIGUSR2)))
+				Consistently(signal2).ShouldNot(Receive())
+			})
+
+			It("returns ErrMemberNotFound for an unknown member", func() {
+				Ω(client.Signal("blah", syscall.SIGUSR2)).Should(Equal(grouper.ErrMemberNotFound{Name: "blah"}))
+			})
+		})
+
+		Describe("Remove", func() {
+			It("stops the member without stopping the group", func() {
+				removed := make(chan error, 1)
+				go func() {
+					removed <- client.Remove("child1")
+				}()
+
+				Eventually(signal1).Should(Receive(Equal(os.Interrupt)))
+				Consistently(removed).ShouldNot(Receive())
+
+				childRunner1.TriggerExit(errors.New("Fail"))
+				Eventually(removed).Should(Receive(Equal(errors.New("Fail"))))
+
+				Consistently(signal2).ShouldNot(Receive())
+				Consistently(poolProcess.Wait()).ShouldNot(Receive())
+				_, ok := client.Get("child1")
+				Ω(ok).Should(BeFalse())
+			})
+
+			It("frees capacity for new members", func() {
+				go client.Remove("child1")
+				Eventually(signal1).Should(Receive())
+				childRunner1.TriggerExit(nil)
+
+				Eventually(client.Inserter()).Should(BeSent(grouper.Member{Name: "child3", Runner: childRunner3}))
+				Eventually(childRunner3.RunCallCount).Should(Equal(1))
+			})
+
+			It("returns ErrMemberNotFound for an unknown member", func() {
+				Ω(client.Remove("blah")).Should(Equal(grouper.ErrMemberNotFound{Name: "blah"}))
+			})
+		})
+
+		Describe("Replace", func() {
+			var replaced chan error
+
+			BeforeEach(func() {
+				replaced = make(chan error, 1)
+				go func() {
+					replaced <- client.Replace("child1", childRunner3)
+				}()
+				Eventually(childRunner3.RunCallCount).Should(Equal(1))
+			})
+
+			It("stops the old process once the new one is ready", func() {
+				Consistently(signal1).ShouldNot(Receive())
+
+				childRunner3.TriggerReady()
+				Eventually(signal1).Should(Receive(Equal(os.Interrupt)))
+				Consistently(replaced).ShouldNot(Receive())
+
+				childRunner1.TriggerExit(nil)
+				Eventually(replaced).Should(Receive(BeNil()))
+
+				signal3 := childRunner3.WaitForCall()
+				Ω(client.Signal("child1", syscall.SIGUSR2)).Should(Succeed())
+				Eventually(signal3).Should(Receive(Equal(syscall.SIGUSR2)))
+				Consistently(poolProcess.Wait()).ShouldNot(Receive())
+			})
+
+			It("keeps the old process if the new one exits before it is ready", func() {
+				childRunner3.TriggerExit(errors.New("Fail"))
+				Eventually(replaced).Should(Receive(Equal(grouper.ErrMemberExited{Name: "child1", Err: errors.New("Fail")})))
+
+				Consistently(signal1).ShouldNot(Receive())
+				Consistently(poolProcess.Wait()).ShouldNot(Receive())
+				Ω(client.Signal("child1", syscall.SIGUSR2)).Should(Succeed())
+				Eventually(signal1).Should(Receive(Equal(syscall.SIGUSR2)))
+			})
+		})
+	})
+	Describe("replacing a member whose process exits first", func() {
+		var member1, member2 grouper.Member
+
+		BeforeEach(func() {
+			member1 = grouper.Member{Name: "child1", Runner: childRunner1, Labels: grouper.Labels{"tier": "web"}}
+			member2 = grouper.Member{Name: "child2", Runner: childRunner2}
+
+			pool = grouper.NewDynamic(nil, 2, 10)
+			client = pool.Client()
+			poolProcess = ifrit.Invoke(pool)
+
+			Ω(client.TryInsert(member1)).Should(Succeed())
+			childRunner1.WaitForCall()
+			childRunner1.TriggerReady()
+			Ω(client.TryInsert(member2)).Should(Succeed())
+			childRunner2.WaitForCall()
+			childRunner2.TriggerReady()
+		})
+
+		AfterEach(func() {
+			poolProcess.Signal(os.Kill)
+			Eventually(func() <-chan struct{} {
+				childRunner1.EnsureExit()
+				childRunner2.EnsureExit()
+				childRunner3.EnsureExit()
+				return poolProcess.Done()
+			}).Should(BeClosed())
+		})
+
+		It("keeps the replacement among the group's members", func() {
+			replaced := make(chan error, 1)
+			go func() {
+				replaced <- client.Replace("child1", childRunner3)
+			}()
+			childRunner3.WaitForCall()
+
+			childRunner1.TriggerExit(nil)
+			Eventually(func() []grouper.MemberStatus {
+				return client.Members()
+			}).Should(HaveLen(1))
+
+			childRunner3.TriggerReady()
+			Eventually(replaced).Should(Receive(BeNil()))
+
+			statuses := client.Members()
+			Ω(statuses).Should(HaveLen(2))
+			Ω(statuses[1].Member.Name).Should(Equal("child1"))
+			Ω(client.Selected(grouper.Selector{"tier": "web"})).Should(HaveLen(1))
+			Ω(client.Tree().String()).Should(ContainSubstring("child1"))
+
+			Ω(client.SetCapacity(1, grouper.StopNewest)).Should(Succeed())
+			signal3 := childRunner3.WaitForCall()
+			Eventually(signal3).Should(Receive(Equal(os.Interrupt)))
+		})
+	})
+
+	Describe("TryInsert and Insert", func() {
+		var member1, member2, member3 grouper.Member
+
+		BeforeEach(func() {
+			member1 = grouper.Member{Name: "child1", Runner: childRunner1}
+			member2 = grouper.Member{Name: "child2", Runner: childRunner2}
+			member3 = grouper.Member{Name: "child3", Runner: childRunner3}
+
+			pool = grouper.NewDynamic(nil, 2, 10)
+			client = pool.Client()
+			poolProcess = ifrit.Invoke(pool)
+
+			Ω(client.TryInsert(member1)).Should(Succeed())
+			Eventually(childRunner1.RunCallCount).Should(Equal(1))
+		})
+
+		AfterEach(func() {
+			poolProcess.Signal(os.Kill)
+			Eventually(func() <-chan struct{} {
+				childRunner1.EnsureExit()
+				childRunner2.EnsureExit()
+				childRunner3.EnsureExit()
+				return poolProcess.Done()
+			}).Should(BeClosed())
+		})
+
+		It("returns ErrGroupFull instead of blocking when the group is full", func() {
+			Ω(client.TryInsert(member2)).Should(Succeed())
+			Ω(client.TryInsert(member3)).Should(Equal(grouper.ErrGroupFull))
+		})
+
+		It("returns ErrDuplicateName when the member is already running", func() {
+			Ω(client.TryInsert(member1)).Should(Equal(grouper.ErrDuplicateName{Name: "child1"}))
+			Ω(client.Insert(context.Background(), member1)).Should(Equal(grouper.ErrDuplicateName{Name: "child1"}))
+		})
+
+		It("emits an exit event for duplicates sent to the Inserter", func() {
+			exits := client.ExitListener()
+			Eventually(client.Inserter()).Should(BeSent(member1))
+			Eventually(exits).Should(Receive(matchExitEvent(member1, grouper.ErrDuplicateName{Name: "child1"})))
+			Ω(childRunner1.RunCallCount()).Should(Equal(1))
+		})
+
+		It("returns ErrGroupNotRunning instead of blocking before the group runs", func() {
+			idle := grouper.NewDynamic(nil, 2, 10)
+			Ω(idle.Client().TryInsert(member2)).Should(Equal(grouper.ErrGroupNotRunning))
+		})
+
+		It("returns ErrGroupClosed once the group is closed", func() {
+			client.Close()
+			Eventually(func() error {
+				return client.TryInsert(member2)
+			}).Should(Equal(grouper.ErrGroupClosed))
+			Ω(client.Insert(context.Background(), member2)).Should(Equal(grouper.ErrGroupClosed))
+		})
+
+		Context("when the group is full", func() {
+			BeforeEach(func() {
+				Ω(client.TryInsert(member2)).Should(Succeed())
+			})
+
+			It("waits for room when inserting with a context", func() {
+				inserted := make(chan error, 1)
+				go func() {
+					inserted <- client.Insert(context.Background(), member3)
+				}()
+				Consistently(inserted).ShouldNot(Receive())
+
+				childRunner1.TriggerExit(nil)
+				Eventually(inserted).Should(Receive(BeNil()))
+				Eventually(childRunner3.RunCallCount).Should(Equal(1))
+			})
+
+			It("stops waiting once the context is done", func() {
+				ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+				defer cancel()
+
+				Ω(client.Insert(ctx, member3)).Should(Equal(context.DeadlineExceeded))
+				Ω(childRunner3.RunCallCount()).Should(Equal(0))
+			})
+		})
+	})
+
+	Describe("SetCapacity", func() {
+		var (
+			member1, member2, member3 grouper.Member
+			signals1, signals2        <-chan os.Signal
+		)
+
+		BeforeEach(func() {
+			member1 = grouper.Member{Name: "child1", Runner: childRunner1}
+			member2 = grouper.Member{Name: "child2", Runner: childRunner2}
+			member3 = grouper.Member{Name: "child3", Runner: childRunner3}
+
+			pool = grouper.NewDynamic(nil, 2, 10)
+			client = pool.Client()
+			poolProcess = ifrit.Invoke(pool)
+
+			Ω(client.TryInsert(member1)).Should(Succeed())
+			signals1 = childRunner1.WaitForCall()
+			Ω(client.TryInsert(member2)).Should(Succeed())
+			signals2 = childRunner2.WaitForCall()
+		})
+
+		AfterEach(func() {
+			poolProcess.Signal(os.Kill)
+			Eventually(func() <-chan struct{} {
+				childRunner1.EnsureExit()
+				childRunner2.EnsureExit()
+				childRunner3.EnsureExit()
+				return poolProcess.Done()
+			}).Should(BeClosed())
+		})
+
+		It("admits waiting inserts as soon as it is raised", func() {
+			inserted := make(chan error, 1)
+			go func() {
+				inserted <- client.Insert(context.Background(), member3)
+			}()
+			Consistently(inserted).ShouldNot(Receive())
+
+			Ω(client.SetCapacity(3, grouper.KeepMembers)).Should(Succeed())
+			Eventually(inserted).Should(Receive(BeNil()))
+			Eventually(childRunner3.RunCallCount).Should(Equal(1))
+		})
+
+		It("stops admitting members until enough have exited when it is lowered", func() {
+			Ω(client.SetCapacity(1, grouper.KeepMembers)).Should(Succeed())
+			Consistently(signals1).ShouldNot(Receive())
+			Consistently(signals2).ShouldNot(Receive())
+
+			childRunner1.TriggerExit(nil)
+			Eventually(func() bool {
+				_, ok := client.Get("child1")
+				return ok
+			}).Should(BeFalse())
+			Ω(client.TryInsert(member3)).Should(Equal(grouper.ErrGroupFull))
+
+			childRunner2.TriggerExit(nil)
+			Eventually(func() error {
+				return client.TryInsert(member3)
+			}).Should(Succeed())
+		})
+
+		It("stops the newest members to meet the new capacity", func() {
+			exits := client.ExitListener()
+
+			Ω(client.SetCapacity(1, grouper.StopNewest)).Should(Succeed())
+			Eventually(signals2).Should(Receive(Equal(os.Interrupt)))
+			Consistently(signals1).ShouldNot(Receive())
+
+			childRunner2.TriggerExit(nil)
+			Eventually(exits).Should(Receive(matchExitEvent(member2, nil)))
+			Consistently(poolProcess.Wait()).ShouldNot(Receive())
+			Ω(client.TryInsert(member3)).Should(Equal(grouper.ErrGroupFull))
+		})
+
+		It("stops the oldest members to meet the new capacity", func() {
+			Ω(client.SetCapacity(1, grouper.StopOldest)).Should(Succeed())
+			Eventually(signals1).Should(Receive(Equal(os.Interrupt)))
+			Consistently(signals2).ShouldNot(Receive())
+
+			childRunner1.TriggerExit(nil)
+			Eventually(func() bool {
+				_, ok := client.Get("child1")
+				return ok
+			}).Should(BeFalse())
+			Ω(client.TryInsert(member3)).Should(Equal(grouper.ErrGroupFull))
+		})
+
+		It("does not stop members which are already leaving the group", func() {
+			removed := make(chan error, 1)
+			go func() {
+				removed <- client.Remove("child2")
+			}()
+			Eventually(signals2).Should(Receive(Equal(os.Interrupt)))
+
+			Ω(client.SetCapacity(1, grouper.StopNewest)).Should(Succeed())
+			Consistently(signals1).ShouldNot(Receive())
+
+			childRunner2.TriggerExit(nil)
+			Eventually(removed).Should(Receive(BeNil()))
+		})
+	})
+
+	Describe("ReadyTimeout", func() {
+		var member1 grouper.Member
+
+		BeforeEach(func() {
+			member1 = grouper.Member{Name: "child1", Runner: childRunner1, ReadyTimeout: 50 * time.Millisecond}
+
+			pool = grouper.NewDynamic(nil, 1, 1)
+			client = pool.Client()
+			poolProcess = ifrit.Invoke(pool)
+		})
+
+		It("abandons a member which ignores its signals, and reports it", func() {
+			exits := client.ExitListener()
+			Ω(client.TryInsert(member1)).Should(Succeed())
+
+			signals1 := childRunner1.WaitForCall()
+			Eventually(signals1).Should(Receive(Equal(os.Interrupt)))
+			Eventually(signals1).Should(Receive(Equal(os.Kill)))
+
+			timeoutErr := grouper.ErrReadyTimeout{Name: "child1", Timeout: 50 * time.Millisecond}
+			Eventually(exits).Should(Receive(matchExitEvent(member1, timeoutErr)))
+
+			client.Close()
+			Eventually(poolProcess.Wait()).Should(Receive())
+		})
+
+		It("does not enforce the timeout on a member which is being removed", func() {
+			exits := client.ExitListener()
+			Ω(client.TryInsert(member1)).Should(Succeed())
+			signals1 := childRunner1.WaitForCall()
+
+			removed := make(chan error, 1)
+			go func() {
+				removed <- client.Remove("child1")
+			}()
+			Eventually(signals1).Should(Receive(Equal(os.Interrupt)))
+			Consistently(signals1, 150*time.Millisecond).ShouldNot(Receive())
+
+			childRunner1.TriggerExit(nil)
+			Eventually(removed).Should(Receive(BeNil()))
+			Eventually(exits).Should(Receive(matchExitEvent(member1, nil)))
+
+			client.Close()
+			Eventually(poolProcess.Wait()).Should(Receive())
+		})
+	})
 })
@@ -72,3 +72,15 @@ func (b *entranceEventBroadcaster) Close() {
 	}
 	b.channels = nil
 }
+
+// Reopen lets a closed broadcaster attach listeners again, and forgets the
+// events it broadcast before it was closed.
+func (b *entranceEventBroadcaster) Reopen() {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if b.channels == nil {
+		b.channels = make([]entranceEventChannel, 0)
+		b.buffer = newSlidingBuffer(b.bufferSize)
+	}
+}
@@ -1,8 +1,14 @@
 package grouper
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"os"
 	"sync"
+	"time"
+
+	"github.com/tedsuo/ifrit"
 )
 
 /*
@@ -11,6 +17,39 @@ An ExitEvent occurs every time an invoked member exits.
 type ExitEvent struct {
 	Member Member
 	Err    error
+
+	// Cause is the reason the member was signaled, or nil if it exited on its own.
+	Cause error
+
+	// Signal is the first signal the member was sent, or nil if it exited on
+	// its own.
+	Signal os.Signal
+
+	StartedAt time.Time
+	ExitedAt  time.Time
+
+	// Stage is the member's stage in a staged group, numbered from 1, or zero
+	// for members of other groups.
+	Stage int
+}
+
+// newExitEvent records the exit of a member, along with how it was stopped.
+func newExitEvent(member Member, process ifrit.Process, err error) ExitEvent {
+	status := process.Status()
+
+	var signal os.Signal
+	if len(status.Signals) > 0 {
+		signal = status.Signals[0]
+	}
+
+	return ExitEvent{
+		Member:    member,
+		Err:       err,
+		Cause:     status.Cause,
+		Signal:    signal,
+		StartedAt: status.StartedAt,
+		ExitedAt:  status.ExitedAt,
+	}
 }
 
 type exitEventChannel chan ExitEvent
@@ -70,16 +109,39 @@ func (b *exitEventBroadcaster) Close() {
 	b.channels = nil
 }
 
+// Reopen lets a closed broadcaster attach listeners again, and forgets the
+// events it broadcast before it was closed.
+func (b *exitEventBroadcaster) Reopen() {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if b.channels == nil {
+		b.channels = make([]exitEventChannel, 0)
+		b.buffer = newSlidingBuffer(b.bufferSize)
+	}
+}
+
+/*
+An ErrorTrace records the exit of every member of a group, in the order the
+members exited.  It implements multi-error unwrapping, so errors.Is and
+errors.As find errors returned by any member, including members of nested
+groups.
+*/
 type ErrorTrace []ExitEvent
 
 func (trace ErrorTrace) Error() string {
 	msg := "Exit trace for group:\n"
 
-	for _, exit := range trace {
+	for _, exit := range trace.Flatten() {
+		name := exit.Member.Name
+		if exit.Stage > 0 {
+			name = fmt.Sprintf("%s (stage %d)", name, exit.Stage)
+		}
+
 		if exit.Err == nil {
-			msg += fmt.Sprintf("%s exited with nil\n", exit.Member.Name)
+			msg += fmt.Sprintf("%s exited with nil\n", name)
 		} else {
-			msg += fmt.Sprintf("%s exited with error: %s\n", exit.Member.Name, exit.Err.Error())
+			msg += fmt.Sprintf("%s exited with error: %s\n", name, exit.Err.Error())
 		}
 	}
 
@@ -95,3 +157,77 @@ func (trace ErrorTrace) ErrorOrNil() error {
 
 	return nil
 }
+
+/*
+Unwrap returns the non-nil errors of every member, for use by errors.Is and
+errors.As.
+*/
+func (trace ErrorTrace) Unwrap() []error {
+	errs := []error{}
+	for _, exit := range trace {
+		if exit.Err != nil {
+			errs = append(errs, exit.Err)
+		}
+	}
+	return errs
+}
+
+/*
+Flatten replaces the exit of every member which is itself a group with the
+exits of that group's members.  The Member.Name of each flattened exit is the
+path to the member, such as "api/http".
+*/
+func (trace ErrorTrace) Flatten() ErrorTrace {
+	flat := ErrorTrace{}
+	for _, exit := range trace {
+		var nested ErrorTrace
+		if !errors.As(exit.Err, &nested) {
+			flat = append(flat, exit)
+			continue
+		}
+
+		for _, nestedExit := range nested.Flatten() {
+			nestedExit.Member.Name = exit.Member.Name + "/" + nestedExit.Member.Name
+			flat = append(flat, nestedExit)
+		}
+	}
+	return flat
+}
+
+type jsonExitEvent struct {
+	Member    string     `json:"member"`
+	Error     string     `json:"error,omitempty"`
+	Cause     string     `json:"cause,omitempty"`
+	Signal    string     `json:"signal,omitempty"`
+	StartedAt *time.Time `json:"started_at,omitempty"`
+	ExitedAt  *time.Time `json:"exited_at,omitempty"`
+	Stage     int        `json:"stage,omitempty"`
+}
+
+/*
+MarshalJSON renders the flattened trace as a list of member exits, suitable
+for structured logs.
+*/
+func (trace ErrorTrace) MarshalJSON() ([]byte, error) {
+	exits := []jsonExitEvent{}
+	for _, exit := range trace.Flatten() {
+		event := jsonExitEvent{Member: exit.Member.Name, Stage: exit.Stage}
+		if exit.Err != nil {
+			event.Error = exit.Err.Error()
+		}
+		if exit.Cause != nil {
+			event.Cause = exit.Cause.Error()
+		}
+		if exit.Signal != nil {
+			event.Signal = exit.Signal.String()
+		}
+		if !exit.StartedAt.IsZero() {
+			event.StartedAt = &exit.StartedAt
+		}
+		if !exit.ExitedAt.IsZero() {
+			event.ExitedAt = &exit.ExitedAt
+		}
+		exits = append(exits, event)
+	}
+	return json.Marshal(exits)
+}
@@ -0,0 +1,123 @@
+package grouper_test
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"time"
+
+	"github.com/tedsuo/ifrit"
+	"github.com/tedsuo/ifrit/fake_runner"
+	"github.com/tedsuo/ifrit/ginkgomon"
+	"github.com/tedsuo/ifrit/grouper"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ErrorTrace", func() {
+	var (
+		errBind  = errors.New("bind: address already in use")
+		nested   grouper.ErrorTrace
+		errTrace grouper.ErrorTrace
+	)
+
+	BeforeEach(func() {
+		nested = grouper.ErrorTrace{
+			{Member: grouper.Member{Name: "http"}, Err: errBind},
+			{Member: grouper.Member{Name: "grpc"}, Signal: os.Interrupt},
+		}
+		errTrace = grouper.ErrorTrace{
+			{Member: grouper.Member{Name: "api"}, Err: nested},
+			{Member: grouper.Member{Name: "db"}},
+		}
+	})
+
+	It("can be searched with errors.Is and errors.As", func() {
+		Ω(errors.Is(errTrace, errBind)).Should(BeTrue())
+		Ω(errors.Is(errTrace, errors.New("other"))).Should(BeFalse())
+
+		var memberExited grouper.ErrMemberExited
+		Ω(errors.As(errTrace, &memberExited)).Should(BeFalse())
+	})
+
+	It("flattens nested traces into member paths", func() {
+		Ω(errTrace.Flatten()).Should(Equal(grouper.ErrorTrace{
+			{Member: grouper.Member{Name: "api/http"}, Err: errBind},
+			{Member: grouper.Member{Name: "api/grpc"}, Signal: os.Interrupt},
+			{Member: grouper.Member{Name: "db"}},
+		}))
+	})
+
+	It("reports the flattened trace in its message", func() {
+		Ω(errTrace.Error()).Should(Equal("Exit trace for group:\n" +
+			"api/http exited with error: bind: address already in use\n" +
+			"api/grpc exited with nil\n" +
+			"db exited with nil\n"))
+	})
+
+	It("renders the flattened trace as JSON", func() {
+		exitedAt := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+		errTrace[1].ExitedAt = exitedAt
+
+		payload, err := json.Marshal(errTrace)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(payload).Should(MatchJSON(`[
+			{"member": "api/http", "error": "bind: address already in use"},
+			{"member": "api/grpc", "signal": "interrupt"},
+			{"member": "db", "exited_at": "2020-01-02T03:04:05Z"}
+		]`))
+	})
+
+	Describe("exits from a running group", func() {
+		var childRunner1, childRunner2 *fake_runner.TestRunner
+		var groupProcess ifrit.Process
+
+		BeforeEach(func() {
+			childRunner1 = fake_runner.NewTestRunner()
+			childRunner2 = fake_runner.NewTestRunner()
+
+			inner := grouper.NewParallel(os.Interrupt, grouper.Members{
+				{Name: "http", Runner: childRunner1},
+			})
+			groupProcess = ifrit.Background(grouper.NewParallel(os.Interrupt, grouper.Members{
+				{Name: "api", Runner: inner},
+				{Name: "db", Runner: childRunner2},
+			}))
+
+			childRunner1.TriggerReady()
+			childRunner2.TriggerReady()
+			Eventually(groupProcess.Ready()).Should(BeClosed())
+		})
+
+		AfterEach(func() {
+			childRunner1.EnsureExit()
+			childRunner2.EnsureExit()
+			ginkgomon.Kill(groupProcess)
+		})
+
+		It("records when each member ran and how it was stopped", func() {
+			signals := childRunner2.WaitForCall()
+			childRunner1.TriggerExit(errBind)
+			Eventually(signals).Should(Receive())
+			childRunner2.TriggerExit(nil)
+
+			var err error
+			Eventually(groupProcess.Wait()).Should(Receive(&err))
+			Ω(errors.Is(err, errBind)).Should(BeTrue())
+
+			flat := err.(grouper.ErrorTrace).Flatten()
+			Ω(flat).Should(HaveLen(2))
+
+			Ω(flat[0].Member.Name).Should(Equal("api/http"))
+			Ω(flat[0].Signal).Should(BeNil())
+			Ω(flat[1].Member.Name).Should(Equal("db"))
+			Ω(flat[1].Signal).Should(Equal(os.Interrupt))
+
+			for _, exit := range flat {
+				Ω(exit.StartedAt).ShouldNot(BeZero())
+				Ω(exit.ExitedAt).Should(BeTemporally(">=", exit.StartedAt))
+			}
+		})
+	})
+})
@@ -3,6 +3,9 @@ package grouper_test
 import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gstruct"
+	"github.com/onsi/gomega/types"
+	"github.com/tedsuo/ifrit/grouper"
 
 	"testing"
 )
@@ -11,3 +14,17 @@ func TestGroup(t *testing.T) {
 	RegisterFailHandler(Fail)
 	RunSpecs(t, "Grouper Suite")
 }
+
+// matchExitEvent matches an ExitEvent by its member and error, ignoring the
+// rest of what was recorded about the exit.
+func matchExitEvent(member grouper.Member, err error) types.GomegaMatcher {
+	errMatcher := BeNil()
+	if err != nil {
+		errMatcher = Equal(err)
+	}
+
+	return gstruct.MatchFields(gstruct.IgnoreExtras, gstruct.Fields{
+		"Member": Equal(member),
+		"Err":    errMatcher,
+	})
+}
@@ -0,0 +1,372 @@
+package grouper
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/tedsuo/ifrit"
+)
+
+/*
+A StandbyMode decides when a hot standby group starts its standby.
+*/
+type StandbyMode int
+
+const (
+	// StartStandbyOnFailover keeps the standby ready to start, and only starts
+	// it once the primary fails.
+	StartStandbyOnFailover StandbyMode = iota
+
+	// PrestartStandby starts the standby alongside the primary, so that it is
+	// already running when the primary fails.
+	PrestartStandby
+)
+
+const (
+	// hotStandbyEventBuffer is the number of events a hot standby group's
+	// client retains for new listeners.
+	hotStandbyEventBuffer = 16
+
+	// hotStandbyTraceLimit is the number of exits a hot standby group's
+	// ErrorTrace retains.
+	hotStandbyTraceLimit = 64
+
+	defaultFailoverBackoff    = 100 * time.Millisecond
+	defaultMaxFailoverBackoff = 10 * time.Second
+)
+
+/*
+NewHotStandby runs a primary member, and fails over to a standby member when
+the primary exits with an error.  Standbys are created by newStandby, and
+started according to mode; once a standby is promoted, a new standby is
+created in its place.  The group becomes ready when its first primary does,
+and stays ready across failovers.  Use a hot standby group to fail over a
+singleton service within one process.
+
+The group stops when it is signaled, or when the primary exits without an
+error.  A prestarted standby which exits, or does not become ready within its
+ReadyTimeout, is recorded, and replaced on the next failover.  A standby whose
+name is already running is not started, and is recorded with ErrDuplicateName.
+Every exit, including those of failed primaries, is recorded in the group's
+ErrorTrace, which retains only the most recent exits.
+
+Failovers are paced by WithFailoverBackoff, which defaults to a backoff of
+100ms up to 10s, and may be limited with WithMaxFailovers.
+
+Event listeners of the group's client must be drained, since the number of
+members it runs is not known in advance.
+*/
+func NewHotStandby(terminationSignal os.Signal, primary Member, newStandby func() Member, mode StandbyMode, opts ...Option) StaticGroup {
+	opts = append([]Option{WithFailoverBackoff(defaultFailoverBackoff, defaultMaxFailoverBackoff)}, opts...)
+	return &hotStandbyGroup{
+		terminationSignal: terminationSignal,
+		primary:           primary,
+		newStandby:        newStandby,
+		mode:              mode,
+		client:            newStaticClient(hotStandbyEventBuffer),
+		options:           newOptions(opts),
+	}
+}
+
+/*
+ErrFailoverLimit is the cause given to the standby of a hot standby group
+which stops because its primary failed again after WithMaxFailovers
+consecutive failovers.
+*/
+type ErrFailoverLimit struct {
+	Failovers int
+}
+
+func (e ErrFailoverLimit) Error() string {
+	return fmt.Sprintf("primary failed after %d consecutive failovers", e.Failovers)
+}
+
+type hotStandbyGroup struct {
+	terminationSignal os.Signal
+	primary           Member
+	newStandby        func() Member
+	mode              StandbyMode
+	client            staticClient
+	options
+}
+
+// standbyMember is a running member of a hot standby group.
+type standbyMember struct {
+	Member
+	process ifrit.Process
+	ready   bool
+	timer   readyTimer
+}
+
+// An unreadyExit reports the exit of a member which did not become ready in
+// time.
+type unreadyExit struct {
+	exit    ExitEvent
+	primary bool
+}
+
+func (g *hotStandbyGroup) Client() StaticClient {
+	return g.client
+}
+
+func (g *hotStandbyGroup) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	defer g.client.closeBroadcasters()
+	g.client.reset()
+
+	var errTrace ErrorTrace
+	var standby *standbyMember
+	backoff := failoverBackoff{options: g.options}
+
+	primary := g.start(signals, g.primary)
+	if g.mode == PrestartStandby {
+		standby, errTrace = g.startStandby(signals, primary.Name, errTrace)
+	}
+
+	// failover promotes the standby, starting one if there is none, and
+	// replaces it when the mode calls for a running standby.
+	failover := func() {
+		primary, standby = standby, nil
+		if primary == nil {
+			primary = g.start(signals, g.newStandby())
+		} else if primary.ready {
+			backoff.primaryReady()
+		}
+		if g.mode == PrestartStandby {
+			standby, errTrace = g.startStandby(signals, primary.Name, errTrace)
+		}
+	}
+
+	// Members which do not become ready in time are stopped off the group's
+	// goroutine, so that signals are still handled, and report their exit on
+	// unready.  While there is no primary, the group is waiting for a failed
+	// primary to be stopped, or for the failover backoff to elapse.
+	unready := make(chan unreadyExit)
+	numUnready := 0
+	var retry *time.Timer
+	defer func() {
+		if retry != nil {
+			retry.Stop()
+		}
+	}()
+
+	for {
+		var primaryReady, primaryDone <-chan struct{}
+		var primaryTimeout <-chan time.Time
+		if primary != nil {
+			primaryDone = primary.process.Done()
+			primaryTimeout = primary.timer.C()
+			if !primary.ready {
+				primaryReady = primary.process.Ready()
+			}
+		}
+
+		var standbyReady, standbyDone <-chan struct{}
+		var standbyTimeout <-chan time.Time
+		if standby != nil {
+			standbyDone = standby.process.Done()
+			standbyTimeout = standby.timer.C()
+			if !standby.ready {
+				standbyReady = standby.process.Ready()
+			}
+		}
+
+		var retryC <-chan time.Time
+		if retry != nil {
+			retryC = retry.C
+		}
+
+		primaryFailed := false
+
+		select {
+		case <-primaryReady:
+			g.memberReady(primary)
+			backoff.primaryReady()
+			if ready != nil {
+				close(ready)
+				ready = nil
+			}
+
+		case <-standbyReady:
+			g.memberReady(standby)
+
+		case <-primaryTimeout:
+			g.stopUnready(primary, true, unready)
+			numUnready++
+			primary = nil
+
+		case <-standbyTimeout:
+			g.stopUnready(standby, false, unready)
+			numUnready++
+			standby = nil
+
+		case stopped := <-unready:
+			numUnready--
+			errTrace = g.memberExited(errTrace, stopped.exit)
+			primaryFailed = stopped.primary
+
+		case <-primaryDone:
+			exit := newExitEvent(primary.Member, primary.process, primary.process.Err())
+			errTrace = g.memberExited(errTrace, exit)
+			primary = nil
+			if exit.Err == nil {
+				cause := ErrMemberExited{Name: exit.Member.Name}
+				return g.stop(g.terminationSignal, cause, signals, errTrace, unready, numUnready, standby).ErrorOrNil()
+			}
+			primaryFailed = true
+
+		case <-standbyDone:
+			errTrace = g.memberExited(errTrace, newExitEvent(standby.Member, standby.process, standby.process.Err()))
+			standby = nil
+
+		case <-retryC:
+			retry = nil
+			failover()
+
+		case signal := <-signals:
+			return g.stop(signal, ifrit.Cause(signals), signals, errTrace, unready, numUnready, primary, standby).ErrorOrNil()
+		}
+
+		if primaryFailed {
+			delay, ok := backoff.next()
+			if !ok {
+				cause := ErrFailoverLimit{Failovers: g.maxFailovers}
+				return g.stop(g.terminationSignal, cause, signals, errTrace, unready, numUnready, standby).ErrorOrNil()
+			}
+			if delay > 0 {
+				retry = time.NewTimer(delay)
+			} else {
+				failover()
+			}
+		}
+	}
+}
+
+func (g *hotStandbyGroup) start(signals <-chan os.Signal, member Member) *standbyMember {
+	process := member.start(signals)
+	g.client.started(member, process)
+	return &standbyMember{
+		Member:  member,
+		process: process,
+		timer:   startReadyTimer(member),
+	}
+}
+
+// startStandby creates and starts a standby, unless its name is already used
+// by the primary.
+func (g *hotStandbyGroup) startStandby(signals <-chan os.Signal, primaryName string, errTrace ErrorTrace) (*standbyMember, ErrorTrace) {
+	member := g.newStandby()
+	if member.Name == primaryName {
+		exit := ExitEvent{Member: member, Err: ErrDuplicateName{member.Name}}
+		g.client.exitBroadcaster.Broadcast(exit)
+		return nil, traceExit(errTrace, exit)
+	}
+	return g.start(signals, member), errTrace
+}
+
+// stopUnready stops a member which did not become ready in time in the
+// background, and reports its exit on unready.
+func (g *hotStandbyGroup) stopUnready(member *standbyMember, primary bool, unready chan<- unreadyExit) {
+	go func() {
+		exit := stopUnready(member.Member, member.process, g.terminationSignal)
+		unready <- unreadyExit{exit: exit, primary: primary}
+	}()
+}
+
+func (g *hotStandbyGroup) memberReady(member *standbyMember) {
+	member.ready = true
+	member.timer.Stop()
+	g.client.broadcastEntrance(EntranceEvent{Member: member.Member, Process: member.process})
+}
+
+func (g *hotStandbyGroup) memberExited(errTrace ErrorTrace, exit ExitEvent) ErrorTrace {
+	g.client.exited(exit)
+	return traceExit(errTrace, exit)
+}
+
+// traceExit appends an exit to a hot standby group's ErrorTrace, dropping the
+// oldest exits beyond hotStandbyTraceLimit.
+func traceExit(errTrace ErrorTrace, exit ExitEvent) ErrorTrace {
+	errTrace = append(errTrace, exit)
+	if len(errTrace) > hotStandbyTraceLimit {
+		errTrace = errTrace[len(errTrace)-hotStandbyTraceLimit:]
+	}
+	return errTrace
+}
+
+// stop stops the running members in parallel, and waits for the members
+// which did not become ready in time to be stopped.
+func (g *hotStandbyGroup) stop(signal os.Signal, cause error, signals <-chan os.Signal, errTrace ErrorTrace, unready <-chan unreadyExit, numUnready int, running ...*standbyMember) ErrorTrace {
+	deadline := g.shutdownDeadline()
+
+	live := make([]*standbyMember, 0, len(running))
+	for _, member := range running {
+		if member != nil {
+			member.timer.Stop()
+			live = append(live, member)
+		}
+	}
+
+	stopped := make(chan memberStop, len(live))
+	for i, member := range live {
+		exit := g.stopMember(member.Member, member.process, signal, cause, deadline)
+		go func(i int) {
+			stopped <- memberStop{index: i, err: <-exit}
+		}(i)
+	}
+
+	for numStopping := len(live); numStopping > 0 || numUnready > 0; {
+		select {
+		case stop := <-stopped:
+			numStopping--
+			member := live[stop.index]
+			errTrace = g.memberExited(errTrace, newExitEvent(member.Member, member.process, stop.err))
+
+		case exit := <-unready:
+			numUnready--
+			errTrace = g.memberExited(errTrace, exit.exit)
+
+		case signal = <-signals:
+			for _, member := range live {
+				member.process.Signal(signal)
+			}
+		}
+	}
+
+	return errTrace
+}
+
+// failoverBackoff paces the failovers of a hot standby group.
+type failoverBackoff struct {
+	options
+	failovers int
+	readyAt   time.Time
+}
+
+// primaryReady records that the current primary has become ready.
+func (b *failoverBackoff) primaryReady() {
+	b.readyAt = time.Now()
+}
+
+// next counts a failover, and returns how long to wait before it.  It returns
+// false once the group has failed over maxFailovers times in a row.
+func (b *failoverBackoff) next() (time.Duration, bool) {
+	if !b.readyAt.IsZero() && time.Since(b.readyAt) >= b.maxFailoverBackoff {
+		b.failovers = 0
+	}
+	b.readyAt = time.Time{}
+
+	if b.maxFailovers > 0 && b.failovers >= b.maxFailovers {
+		return 0, false
+	}
+
+	var delay time.Duration
+	if b.failovers > 0 && b.failoverBackoff > 0 {
+		delay = b.failoverBackoff << (b.failovers - 1)
+		if delay <= 0 || delay > b.maxFailoverBackoff {
+			delay = b.maxFailoverBackoff
+		}
+	}
+	b.failovers++
+	return delay, true
+}
@@ -0,0 +1,303 @@
+package grouper_test
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/tedsuo/ifrit"
+	"github.com/tedsuo/ifrit/fake_runner"
+	"github.com/tedsuo/ifrit/ginkgomon"
+	"github.com/tedsuo/ifrit/grouper"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Hot Standby Group", func() {
+	var (
+		groupProcess ifrit.Process
+		client       grouper.StaticClient
+
+		primaryRunner  *fake_runner.TestRunner
+		standbyLock    sync.Mutex
+		standbyRunners []*fake_runner.TestRunner
+		standbys       grouper.Members
+
+		primary             grouper.Member
+		newStandby          func() grouper.Member
+		standbyReadyTimeout time.Duration
+		mode                grouper.StandbyMode
+		opts                []grouper.Option
+	)
+
+	BeforeEach(func() {
+		primaryRunner = fake_runner.NewTestRunner()
+		primary = grouper.Member{Name: "primary", Runner: primaryRunner}
+
+		standbyRunners = nil
+		standbys = nil
+		standbyReadyTimeout = 0
+		opts = nil
+		newStandby = func() grouper.Member {
+			standbyLock.Lock()
+			defer standbyLock.Unlock()
+
+			runner := fake_runner.NewTestRunner()
+			member := grouper.Member{Name: fmt.Sprintf("standby%d", len(standbys)+1), Runner: runner, ReadyTimeout: standbyReadyTimeout}
+			standbyRunners = append(standbyRunners, runner)
+			standbys = append(standbys, member)
+			return member
+		}
+	})
+
+	numStandbys := func() int {
+		standbyLock.Lock()
+		defer standbyLock.Unlock()
+		return len(standbyRunners)
+	}
+
+	standbyRunner := func(i int) *fake_runner.TestRunner {
+		standbyLock.Lock()
+		defer standbyLock.Unlock()
+		return standbyRunners[i]
+	}
+
+	JustBeforeEach(func() {
+		group := grouper.NewHotStandby(os.Interrupt, primary, newStandby, mode, opts...)
+		client = group.Client()
+		groupProcess = ifrit.Background(group)
+	})
+
+	AfterEach(func() {
+		groupProcess.Signal(os.Kill)
+		Eventually(func() <-chan struct{} {
+			primaryRunner.EnsureExit()
+			for i := 0; i < numStandbys(); i++ {
+				standbyRunner(i).EnsureExit()
+			}
+			return groupProcess.Done()
+		}).Should(BeClosed())
+		ginkgomon.Kill(groupProcess)
+	})
+
+	Context("when the standby starts on failover", func() {
+		BeforeEach(func() {
+			mode = grouper.StartStandbyOnFailover
+		})
+
+		JustBeforeEach(func() {
+			primaryRunner.TriggerReady()
+			Eventually(groupProcess.Ready()).Should(BeClosed())
+		})
+
+		It("does not start a standby while the primary is running", func() {
+			Consistently(func() int { return len(client.EntranceListener()) }).Should(Equal(1))
+			_, ok := client.Get("standby1")
+			Ω(ok).Should(BeFalse())
+		})
+
+		It("starts a standby in place of a failed primary, and stays ready", func() {
+			primaryRunner.TriggerExit(errors.New("Fail"))
+			Eventually(func() bool {
+				_, ok := client.Get("standby1")
+				return ok
+			}).Should(BeTrue())
+			Consistently(groupProcess.Wait()).ShouldNot(Receive())
+
+			standbySignals := standbyRunner(0).WaitForCall()
+			groupProcess.Signal(os.Interrupt)
+			Eventually(standbySignals).Should(Receive(Equal(os.Interrupt)))
+			standbyRunner(0).TriggerExit(nil)
+
+			var err error
+			Eventually(groupProcess.Wait()).Should(Receive(&err))
+			Ω(err).Should(ConsistOf(
+				matchExitEvent(primary, errors.New("Fail")),
+				matchExitEvent(standbys[0], nil),
+			))
+		})
+
+		It("stops when the primary exits without an error", func() {
+			primaryRunner.TriggerExit(nil)
+			Eventually(groupProcess.Wait()).Should(Receive(BeNil()))
+			Ω(numStandbys()).Should(Equal(0))
+		})
+	})
+
+	Context("when the primary does not become ready in time", func() {
+		BeforeEach(func() {
+			mode = grouper.StartStandbyOnFailover
+			primary.ReadyTimeout = 50 * time.Millisecond
+		})
+
+		It("abandons a primary which ignores its signals, and fails over", func() {
+			primarySignals := primaryRunner.WaitForCall()
+			Eventually(primarySignals).Should(Receive(Equal(os.Interrupt)))
+			Eventually(primarySignals).Should(Receive(Equal(os.Kill)))
+
+			Eventually(numStandbys).Should(Equal(1))
+			standbySignals := standbyRunner(0).WaitForCall()
+			standbyRunner(0).TriggerReady()
+			Eventually(groupProcess.Ready()).Should(BeClosed())
+
+			groupProcess.Signal(os.Interrupt)
+			Eventually(standbySignals).Should(Receive(Equal(os.Interrupt)))
+			standbyRunner(0).TriggerExit(nil)
+
+			var err error
+			Eventually(groupProcess.Wait()).Should(Receive(&err))
+			Ω(err).Should(ConsistOf(
+				matchExitEvent(primary, grouper.ErrReadyTimeout{Name: "primary", Timeout: 50 * time.Millisecond}),
+				matchExitEvent(standbys[0], nil),
+			))
+		})
+	})
+
+	Context("when the standby is prestarted", func() {
+		var primarySignals, standbySignals <-chan os.Signal
+
+		BeforeEach(func() {
+			mode = grouper.PrestartStandby
+		})
+
+		JustBeforeEach(func() {
+			primarySignals = primaryRunner.WaitForCall()
+			Eventually(numStandbys).Should(Equal(1))
+			standbySignals = standbyRunner(0).WaitForCall()
+		})
+
+		It("becomes ready once the primary is ready", func() {
+			standbyRunner(0).TriggerReady()
+			Consistently(groupProcess.Ready()).ShouldNot(BeClosed())
+
+			primaryRunner.TriggerReady()
+			Eventually(groupProcess.Ready()).Should(BeClosed())
+		})
+
+		It("promotes the standby when the primary fails, and prestarts a new one", func() {
+			primaryRunner.TriggerReady()
+			standbyRunner(0).TriggerReady()
+			Eventually(groupProcess.Ready()).Should(BeClosed())
+
+			primaryRunner.TriggerExit(errors.New("Fail"))
+			Eventually(numStandbys).Should(Equal(2))
+			Consistently(standbySignals).ShouldNot(Receive())
+
+			standbyRunner(0).TriggerExit(errors.New("Fail again"))
+			Eventually(numStandbys).Should(Equal(3))
+			Consistently(groupProcess.Wait()).ShouldNot(Receive())
+		})
+
+		It("stops the primary and the standby when it is signaled", func() {
+			primaryRunner.TriggerReady()
+			Eventually(groupProcess.Ready()).Should(BeClosed())
+
+			groupProcess.Signal(os.Interrupt)
+			Eventually(primarySignals).Should(Receive(Equal(os.Interrupt)))
+			Eventually(standbySignals).Should(Receive(Equal(os.Interrupt)))
+			primaryRunner.TriggerExit(nil)
+			standbyRunner(0).TriggerExit(nil)
+			Eventually(groupProcess.Wait()).Should(Receive(BeNil()))
+		})
+
+		Context("when the standby does not become ready in time", func() {
+			BeforeEach(func() {
+				standbyReadyTimeout = 50 * time.Millisecond
+			})
+
+			It("stops and records the standby", func() {
+				Eventually(standbySignals).Should(Receive(Equal(os.Interrupt)))
+				standbyRunner(0).TriggerExit(nil)
+				Consistently(primarySignals).ShouldNot(Receive())
+
+				groupProcess.Signal(os.Interrupt)
+				Eventually(primarySignals).Should(Receive(Equal(os.Interrupt)))
+				primaryRunner.TriggerExit(nil)
+
+				var err error
+				Eventually(groupProcess.Wait()).Should(Receive(&err))
+				Ω(err).Should(ConsistOf(
+					matchExitEvent(standbys[0], grouper.ErrReadyTimeout{Name: "standby1", Timeout: 50 * time.Millisecond}),
+					matchExitEvent(primary, nil),
+				))
+			})
+		})
+
+		It("records a standby which exits, and replaces it on the next failover", func() {
+			standbyRunner(0).TriggerExit(errors.New("Fail"))
+			Consistently(primarySignals).ShouldNot(Receive())
+			Ω(numStandbys()).Should(Equal(1))
+
+			primaryRunner.TriggerExit(errors.New("Fail"))
+			Eventually(numStandbys).Should(Equal(3))
+		})
+	})
+
+	Context("when the primary keeps failing", func() {
+		BeforeEach(func() {
+			mode = grouper.StartStandbyOnFailover
+		})
+
+		Context("with a failover backoff", func() {
+			BeforeEach(func() {
+				opts = []grouper.Option{grouper.WithFailoverBackoff(200*time.Millisecond, time.Second)}
+			})
+
+			It("fails over immediately, and then backs off", func() {
+				primaryRunner.WaitForCall()
+				primaryRunner.TriggerExit(errors.New("Fail"))
+				Eventually(numStandbys).Should(Equal(1))
+
+				standbyRunner(0).WaitForCall()
+				standbyRunner(0).TriggerExit(errors.New("Fail again"))
+				Consistently(numStandbys, 100*time.Millisecond).Should(Equal(1))
+				Eventually(numStandbys).Should(Equal(2))
+			})
+		})
+
+		Context("with a failover limit", func() {
+			BeforeEach(func() {
+				opts = []grouper.Option{grouper.WithFailoverBackoff(0, 0), grouper.WithMaxFailovers(1)}
+			})
+
+			It("stops once the limit is reached", func() {
+				primaryRunner.WaitForCall()
+				primaryRunner.TriggerExit(errors.New("Fail"))
+				Eventually(numStandbys).Should(Equal(1))
+
+				standbyRunner(0).WaitForCall()
+				standbyRunner(0).TriggerExit(errors.New("Fail again"))
+
+				var err error
+				Eventually(groupProcess.Wait()).Should(Receive(&err))
+				Ω(err).Should(ConsistOf(
+					matchExitEvent(primary, errors.New("Fail")),
+					matchExitEvent(standbys[0], errors.New("Fail again")),
+				))
+				Ω(numStandbys()).Should(Equal(1))
+			})
+		})
+
+		Context("many times", func() {
+			BeforeEach(func() {
+				failing := ifrit.RunFunc(func(signals <-chan os.Signal, ready chan<- struct{}) error {
+					return errors.New("Fail")
+				})
+				primary = grouper.Member{Name: "primary", Runner: failing}
+				newStandby = func() grouper.Member {
+					return grouper.Member{Name: "standby", Runner: failing}
+				}
+				opts = []grouper.Option{grouper.WithFailoverBackoff(0, 0), grouper.WithMaxFailovers(100)}
+			})
+
+			It("retains only the most recent exits", func() {
+				var err error
+				Eventually(groupProcess.Wait()).Should(Receive(&err))
+				Ω(err).Should(HaveLen(64))
+			})
+		})
+	})
+})
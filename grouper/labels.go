@@ -0,0 +1,56 @@
+package grouper
+
+/*
+Labels are arbitrary key/value pairs attached to a Member, such as
+"tier": "ingress" or "tenant": "acme".
+*/
+type Labels map[string]string
+
+/*
+A Selector matches the members whose Labels contain every one of its key/value
+pairs.  An empty Selector matches every member.
+*/
+type Selector map[string]string
+
+/*
+Matches reports whether the labels contain every key/value pair of the
+selector.
+*/
+func (s Selector) Matches(labels Labels) bool {
+	for key, value := range s {
+		if label, ok := labels[key]; !ok || label != value {
+			return false
+		}
+	}
+	return true
+}
+
+// selectEntrances forwards the entrance events of matching members, until
+// events is closed.
+func selectEntrances(events <-chan EntranceEvent, selector Selector) <-chan EntranceEvent {
+	selected := make(chan EntranceEvent, cap(events))
+	go func() {
+		defer close(selected)
+		for event := range events {
+			if selector.Matches(event.Member.Labels) {
+				selected <- event
+			}
+		}
+	}()
+	return selected
+}
+
+// selectExits forwards the exit events of matching members, until events is
+// closed.
+func selectExits(events <-chan ExitEvent, selector Selector) <-chan ExitEvent {
+	selected := make(chan ExitEvent, cap(events))
+	go func() {
+		defer close(selected)
+		for event := range events {
+			if selector.Matches(event.Member.Labels) {
+				selected <- event
+			}
+		}
+	}()
+	return selected
+}
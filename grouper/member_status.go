@@ -0,0 +1,66 @@
+package grouper
+
+import (
+	"time"
+
+	"github.com/tedsuo/ifrit"
+)
+
+/*
+MemberState describes the phase of a dynamic group member's lifecycle.
+*/
+type MemberState int
+
+const (
+	// Invoking members have been started, but have not yet become ready.
+	Invoking MemberState = iota
+
+	// Ready members have become ready, and have not been signaled.
+	Ready
+
+	// Signaled members have received a signal, but have not yet exited.
+	Signaled
+)
+
+func (s MemberState) String() string {
+	switch s {
+	case Invoking:
+		return "invoking"
+	case Ready:
+		return "ready"
+	case Signaled:
+		return "signaled"
+	default:
+		return "unknown"
+	}
+}
+
+/*
+MemberStatus is a snapshot of a dynamic group member.  ReadyAt is zero until
+the member becomes ready.  A replaced member keeps its original InsertedAt.
+*/
+type MemberStatus struct {
+	Member     Member
+	State      MemberState
+	InsertedAt time.Time
+	ReadyAt    time.Time
+}
+
+func newMemberStatus(member Member, process ifrit.Process, insertedAt time.Time) MemberStatus {
+	status := process.Status()
+
+	state := Invoking
+	switch {
+	case len(status.Signals) > 0:
+		state = Signaled
+	case !status.ReadyAt.IsZero():
+		state = Ready
+	}
+
+	return MemberStatus{
+		Member:     member,
+		State:      state,
+		InsertedAt: insertedAt,
+		ReadyAt:    status.ReadyAt,
+	}
+}
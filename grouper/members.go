@@ -2,16 +2,48 @@ package grouper
 
 import (
 	"fmt"
+	"os"
+	"strings"
+	"time"
 
 	"github.com/tedsuo/ifrit"
 )
 
 /*
-A Member associates a unique name with a Runner.
+A Member associates a unique name with a Runner.  Labels describe the member,
+so that clients can act on every member matching a Selector.
+
+If ReadyTimeout is set, the group will only wait that long for the member to
+become ready. See ErrReadyTimeout.
+
+DependsOn names the members which must be ready before this member is started.
+It is only used by DAG groups.
+
+Policy decides whether the member's exit stops a static group.  Members are
+Critical by default.
+
+When a static group stops, it sends the member StopSignal instead of its own
+signal, if set, and follows the member's Escalation instead of the group's.
+If StopTimeout is set, the group waits no longer than that for the member to
+exit, and records it with ErrStopTimeout.
 */
 type Member struct {
 	Name string
 	ifrit.Runner
+	Labels Labels
+
+	ReadyTimeout time.Duration
+	DependsOn    []string
+	Policy       Policy
+
+	StopSignal  os.Signal
+	StopTimeout time.Duration
+	Escalation  ifrit.Escalation
+}
+
+// start runs the member as a named child of the group which owns signals.
+func (m Member) start(signals <-chan os.Signal) ifrit.Process {
+	return ifrit.Background(m, ifrit.ChildOf(signals), ifrit.WithName(m.Name))
 }
 
 /*
@@ -43,6 +75,67 @@ func (m Members) Validate() error {
 	return nil
 }
 
+/*
+ValidateDependencies checks that every member depends only on members in the
+list, and that the dependencies do not form a cycle.  It returns an error of
+type ErrUnknownDependency or ErrDependencyCycle.
+*/
+func (m Members) ValidateDependencies() error {
+	byName := map[string]Member{}
+	for _, member := range m {
+		byName[member.Name] = member
+	}
+
+	for _, member := range m {
+		for _, dependency := range member.DependsOn {
+			if _, found := byName[dependency]; !found {
+				return ErrUnknownDependency{Member: member.Name, Dependency: dependency}
+			}
+		}
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	marks := map[string]int{}
+	path := []string{}
+
+	var visit func(name string) error
+	visit = func(name string) error {
+		switch marks[name] {
+		case visited:
+			return nil
+		case visiting:
+			for i, onPath := range path {
+				if onPath == name {
+					cycle := append(append([]string{}, path[i:]...), name)
+					return ErrDependencyCycle{cycle}
+				}
+			}
+		}
+
+		marks[name] = visiting
+		path = append(path, name)
+		for _, dependency := range byName[name].DependsOn {
+			if err := visit(dependency); err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+		marks[name] = visited
+		return nil
+	}
+
+	for _, member := range m {
+		if err := visit(member.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 /*
 ErrDuplicateNames is returned to indicate two or more members with the same name
 were detected. Because more than one duplicate name may be detected in a single
@@ -69,3 +162,40 @@ func (e ErrDuplicateNames) Error() string {
 
 	return msg
 }
+
+/*
+ErrDuplicateName is returned when a member is inserted into a dynamic group
+which is already running a member with the same name.
+*/
+type ErrDuplicateName struct {
+	Name string
+}
+
+func (e ErrDuplicateName) Error() string {
+	return fmt.Sprintf("Duplicate member name: %s", e.Name)
+}
+
+/*
+ErrUnknownDependency is returned when a member depends on a name which does not
+belong to any member of the group.
+*/
+type ErrUnknownDependency struct {
+	Member     string
+	Dependency string
+}
+
+func (e ErrUnknownDependency) Error() string {
+	return fmt.Sprintf("Member %s depends on unknown member %s", e.Member, e.Dependency)
+}
+
+/*
+ErrDependencyCycle is returned when member dependencies form a cycle.  Cycle
+lists the names along the cycle, starting and ending with the same name.
+*/
+type ErrDependencyCycle struct {
+	Cycle []string
+}
+
+func (e ErrDependencyCycle) Error() string {
+	return fmt.Sprintf("Dependency cycle: %s", strings.Join(e.Cycle, " -> "))
+}
@@ -41,4 +41,39 @@ var _ = Describe("Members", func() {
 			}
 		})
 	})
+	Describe("ValidateDependencies", func() {
+		It("accepts dependencies on other members", func() {
+			members := grouper.Members{
+				{Name: "db"},
+				{Name: "cache"},
+				{Name: "api", DependsOn: []string{"db", "cache"}},
+			}
+			Ω(members.ValidateDependencies()).Should(Succeed())
+		})
+
+		It("rejects dependencies on unknown members", func() {
+			members := grouper.Members{
+				{Name: "api", DependsOn: []string{"db"}},
+			}
+			Ω(members.ValidateDependencies()).Should(Equal(grouper.ErrUnknownDependency{Member: "api", Dependency: "db"}))
+		})
+
+		It("rejects dependency cycles", func() {
+			members := grouper.Members{
+				{Name: "a", DependsOn: []string{"b"}},
+				{Name: "b", DependsOn: []string{"c"}},
+				{Name: "c", DependsOn: []string{"a"}},
+			}
+			err := members.ValidateDependencies()
+			Ω(err).Should(Equal(grouper.ErrDependencyCycle{Cycle: []string{"a", "b", "c", "a"}}))
+			Ω(err.Error()).Should(Equal("Dependency cycle: a -> b -> c -> a"))
+		})
+
+		It("rejects members which depend on themselves", func() {
+			members := grouper.Members{
+				{Name: "a", DependsOn: []string{"a"}},
+			}
+			Ω(members.ValidateDependencies()).Should(Equal(grouper.ErrDependencyCycle{Cycle: []string{"a", "a"}}))
+		})
+	})
 })
@@ -0,0 +1,161 @@
+package grouper
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/tedsuo/ifrit"
+)
+
+/*
+An Option configures a static group.
+*/
+type Option func(*options)
+
+type options struct {
+	escalation     ifrit.Escalation
+	shutdownBudget time.Duration
+	startupWindow  int
+
+	failoverBackoff    time.Duration
+	maxFailoverBackoff time.Duration
+	maxFailovers       int
+}
+
+func newOptions(opts []Option) options {
+	o := options{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+/*
+WithEscalation configures how a group stops members which ignore its signal.
+The group first sends its stop signal, then follows the escalation; the first
+step will usually have a nil Signal, and only set the grace period.  Members
+which outlive the escalation are abandoned, and recorded in the ErrorTrace with
+ifrit.ErrAbandoned.
+*/
+func WithEscalation(escalation ifrit.Escalation) Option {
+	return func(o *options) {
+		o.escalation = escalation
+	}
+}
+
+/*
+WithShutdownBudget bounds how long a group spends stopping its members.  Once
+the budget is spent, members which have not exited are recorded in the
+ErrorTrace with ErrStopTimeout, and the group exits without waiting for them.
+*/
+func WithShutdownBudget(budget time.Duration) Option {
+	return func(o *options) {
+		o.shutdownBudget = budget
+	}
+}
+
+/*
+WithStartupWindow bounds how many members of a parallel group may be starting,
+but not yet ready, at once.  Members are started in order as earlier members
+become ready or exit, and the group is ready once every member is.  A window of
+zero or less starts every member at once.  Other groups ignore this option.
+*/
+func WithStartupWindow(window int) Option {
+	return func(o *options) {
+		o.startupWindow = window
+	}
+}
+
+/*
+WithFailoverBackoff paces the failovers of a hot standby group.  A failover
+after a primary which stayed ready for at least max is immediate; each further
+failover waits, starting at backoff and doubling up to max.  A backoff of zero
+fails over immediately every time.  Other groups ignore this option.
+*/
+func WithFailoverBackoff(backoff, max time.Duration) Option {
+	return func(o *options) {
+		o.failoverBackoff = backoff
+		o.maxFailoverBackoff = max
+	}
+}
+
+/*
+WithMaxFailovers stops a hot standby group once it has failed over n times in
+a row, without a primary staying ready for the maximum failover backoff in
+between; the next failure of the primary stops the group, with an
+ErrFailoverLimit cause.  A limit of zero or less never stops the group.  Other
+groups ignore this option.
+*/
+func WithMaxFailovers(n int) Option {
+	return func(o *options) {
+		o.maxFailovers = n
+	}
+}
+
+/*
+ErrStopTimeout is recorded in a group's ErrorTrace when a member does not exit
+within its StopTimeout, or within the group's shutdown budget.  The member is
+left running.
+*/
+type ErrStopTimeout struct {
+	Name    string
+	Timeout time.Duration
+}
+
+func (e ErrStopTimeout) Error() string {
+	return fmt.Sprintf("member %s did not stop within %s", e.Name, e.Timeout)
+}
+
+// shutdownDeadline returns the time by which the group must finish stopping,
+// or the zero time if it has no shutdown budget.
+func (o options) shutdownDeadline() time.Time {
+	if o.shutdownBudget <= 0 {
+		return time.Time{}
+	}
+	return time.Now().Add(o.shutdownBudget)
+}
+
+// stopMember signals a member, then follows its escalation in the background
+// until it exits or runs out of time.
+func (o options) stopMember(member Member, process ifrit.Process, signal os.Signal, cause error, deadline time.Time) <-chan error {
+	if member.StopSignal != nil {
+		signal = member.StopSignal
+	}
+	ifrit.SignalWithCause(process, signal, cause)
+
+	escalation := o.escalation
+	if member.Escalation != nil {
+		escalation = member.Escalation
+	}
+
+	stopped := make(chan error, 1)
+	go func() {
+		stopped <- escalation.Stop(process)
+	}()
+
+	exit := make(chan error, 1)
+	go func() {
+		var timeout, budget <-chan time.Time
+		if member.StopTimeout > 0 {
+			timer := time.NewTimer(member.StopTimeout)
+			defer timer.Stop()
+			timeout = timer.C
+		}
+		if !deadline.IsZero() {
+			timer := time.NewTimer(time.Until(deadline))
+			defer timer.Stop()
+			budget = timer.C
+		}
+
+		select {
+		case err := <-stopped:
+			exit <- err
+		case <-timeout:
+			exit <- ErrStopTimeout{Name: member.Name, Timeout: member.StopTimeout}
+		case <-budget:
+			exit <- ErrStopTimeout{Name: member.Name, Timeout: o.shutdownBudget}
+		}
+	}()
+	return exit
+}
@@ -2,9 +2,6 @@ package grouper
 
 import (
 	"os"
-	"reflect"
-
-	"github.com/tedsuo/ifrit"
 )
 
 /*
@@ -13,39 +10,36 @@ becomes ready.  On shutdown, it will shut the started processes down in reverse
 Use an ordered group to describe a list of dependent processes, where each process
 depends upon the previous being available in order to function correctly.
 */
-func NewOrdered(terminationSignal os.Signal, members Members) ifrit.Runner {
-	return &orderedGroup{
-		terminationSignal: terminationSignal,
-		pool:              make(map[string]ifrit.Process),
-		members:           members,
-	}
+func NewOrdered(terminationSignal os.Signal, members Members, opts ...Option) StaticGroup {
+	return &orderedGroup{newStaticGroup(terminationSignal, members, opts)}
 }
 
 type orderedGroup struct {
-	terminationSignal os.Signal
-	pool              map[string]ifrit.Process
-	members           Members
+	staticGroup
 }
 
 func (g *orderedGroup) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	defer g.client.closeBroadcasters()
+
 	err := g.validate()
 	if err != nil {
 		return err
 	}
+	g.reset()
 
 	signal, errTrace := g.orderedStart(signals)
-	if errTrace != nil {
-		return g.stop(g.terminationSignal, signals, errTrace)
+	if errTrace.stopsGroup() {
+		return g.stop(g.terminationSignal, shutdownCause(signals, errTrace), signals, errTrace)
 	}
 
 	if signal != nil {
-		return g.stop(signal, signals, errTrace)
+		return g.stop(signal, shutdownCause(signals, errTrace), signals, errTrace)
 	}
 
 	close(ready)
 
 	signal, errTrace = g.waitForSignal(signals, errTrace)
-	return g.stop(signal, signals, errTrace)
+	return g.stop(signal, shutdownCause(signals, errTrace), signals, errTrace)
 }
 
 func (g *orderedGroup) validate() error {
@@ -53,94 +47,20 @@ func (g *orderedGroup) validate() error {
 }
 
 func (g *orderedGroup) orderedStart(signals <-chan os.Signal) (os.Signal, ErrorTrace) {
-	for _, member := range g.members {
-		p := ifrit.Background(member)
-		cases := make([]reflect.SelectCase, 0, len(g.pool)+3)
-		for i := 0; i < len(g.pool); i++ {
-			cases = append(cases, reflect.SelectCase{
-				Dir:  reflect.SelectRecv,
-				Chan: reflect.ValueOf(g.pool[g.members[i].Name].Wait()),
-			})
-		}
-		cases = append(cases, reflect.SelectCase{
-			Dir:  reflect.SelectRecv,
-			Chan: reflect.ValueOf(p.Ready()),
-		})
-
-		cases = append(cases, reflect.SelectCase{
-			Dir:  reflect.SelectRecv,
-			Chan: reflect.ValueOf(p.Wait()),
-		})
-
-		cases = append(cases, reflect.SelectCase{
-			Dir:  reflect.SelectRecv,
-			Chan: reflect.ValueOf(signals),
-		})
-
-		chosen, recv, _ := reflect.Select(cases)
-		g.pool[member.Name] = p
-		switch chosen {
-		case len(cases) - 1:
-			// signals
-			return recv.Interface().(os.Signal), nil
-		case len(cases) - 2:
-			// p.Wait
-			var err error
-			if !recv.IsNil() {
-				err = recv.Interface().(error)
-			}
-			return nil, ErrorTrace{
-				ExitEvent{Member: member, Err: err},
-			}
-		case len(cases) - 3:
-			// p.Ready
-		default:
-			// other member has exited
-			var err error = nil
-			if e := recv.Interface(); e != nil {
-				err = e.(error)
-			}
-			return nil, ErrorTrace{
-				ExitEvent{Member: g.members[chosen], Err: err},
-			}
+	var errTrace ErrorTrace
+	for i := range g.members {
+		var signal os.Signal
+		signal, errTrace = g.startInTurn(signals, i, errTrace)
+		if signal != nil || errTrace.stopsGroup() {
+			return signal, errTrace
 		}
 	}
 
-	return nil, nil
-}
-
-func (g *orderedGroup) waitForSignal(signals <-chan os.Signal, errTrace ErrorTrace) (os.Signal, ErrorTrace) {
-	cases := make([]reflect.SelectCase, 0, len(g.pool)+1)
-	for i := 0; i < len(g.pool); i++ {
-		cases = append(cases, reflect.SelectCase{
-			Dir:  reflect.SelectRecv,
-			Chan: reflect.ValueOf(g.pool[g.members[i].Name].Wait()),
-		})
-	}
-	cases = append(cases, reflect.SelectCase{
-		Dir:  reflect.SelectRecv,
-		Chan: reflect.ValueOf(signals),
-	})
-
-	chosen, recv, _ := reflect.Select(cases)
-	if chosen == len(cases)-1 {
-		return recv.Interface().(os.Signal), errTrace
-	}
-
-	var err error
-	if !recv.IsNil() {
-		err = recv.Interface().(error)
-	}
-
-	errTrace = append(errTrace, ExitEvent{
-		Member: g.members[chosen],
-		Err:    err,
-	})
-
-	return g.terminationSignal, errTrace
+	return nil, errTrace
 }
 
-func (g *orderedGroup) stop(signal os.Signal, signals <-chan os.Signal, errTrace ErrorTrace) error {
+func (g *orderedGroup) stop(signal os.Signal, cause error, signals <-chan os.Signal, errTrace ErrorTrace) error {
+	deadline := g.shutdownDeadline()
 	errOccurred := false
 	exited := map[string]struct{}{}
 	if len(errTrace) > 0 {
@@ -158,15 +78,12 @@ func (g *orderedGroup) stop(signal os.Signal, signals <-chan os.Signal, errTrace
 			continue
 		}
 		if p, ok := g.pool[m.Name]; ok {
-			p.Signal(signal)
+			exit := g.stopMember(m, p, signal, cause, deadline)
 		Exited:
 			for {
 				select {
-				case err := <-p.Wait():
-					errTrace = append(errTrace, ExitEvent{
-						Member: m,
-						Err:    err,
-					})
+				case err := <-exit:
+					errTrace = g.memberExited(errTrace, newExitEvent(m, p, err))
 					if err != nil {
 						errOccurred = true
 					}
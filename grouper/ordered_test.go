@@ -37,9 +37,9 @@ var _ = Describe("Ordered Group", func() {
 			childRunner3 = fake_runner.NewTestRunner()
 
 			members = grouper.Members{
-				{"child1", childRunner1},
-				{"child2", childRunner2},
-				{"child3", childRunner3},
+				{Name: "child1", Runner: childRunner1},
+				{Name: "child2", Runner: childRunner2},
+				{Name: "child3", Runner: childRunner3},
 			}
 
 			groupRunner = grouper.NewOrdered(os.Interrupt, members)
@@ -166,8 +166,8 @@ var _ = Describe("Ordered Group", func() {
 						errTrace := err.(grouper.ErrorTrace)
 						Ω(errTrace).Should(HaveLen(3))
 
-						Ω(errTrace).Should(ContainElement(grouper.ExitEvent{grouper.Member{"child1", childRunner1}, nil}))
-						Ω(errTrace).Should(ContainElement(grouper.ExitEvent{grouper.Member{"child2", childRunner2}, errors.New("Fail")}))
+						Ω(errTrace).Should(ContainElement(matchExitEvent(grouper.Member{Name: "child1", Runner: childRunner1}, nil)))
+						Ω(errTrace).Should(ContainElement(matchExitEvent(grouper.Member{Name: "child2", Runner: childRunner2}, errors.New("Fail"))))
 					})
 				})
 			})
@@ -227,13 +227,221 @@ var _ = Describe("Ordered Group", func() {
 
 				Eventually(groupProcess.Wait()).Should(Receive(&err))
 				errTrace := err.(grouper.ErrorTrace)
-				Ω(errTrace).Should(ContainElement(grouper.ExitEvent{grouper.Member{"child1", childRunner1}, nil}))
-				Ω(errTrace).Should(ContainElement(grouper.ExitEvent{grouper.Member{"child2", childRunner2}, errors.New("Fail")}))
+				Ω(errTrace).Should(ContainElement(matchExitEvent(grouper.Member{Name: "child1", Runner: childRunner1}, nil)))
+				Ω(errTrace).Should(ContainElement(matchExitEvent(grouper.Member{Name: "child2", Runner: childRunner2}, errors.New("Fail"))))
 				Ω(exitIndex("child1", errTrace)).Should(BeNumerically(">", exitIndex("child2", errTrace)))
 			})
 		})
 	})
 
+	Describe("without members", func() {
+		BeforeEach(func() {
+			groupProcess = ifrit.Background(grouper.NewOrdered(os.Interrupt, grouper.Members{}))
+		})
+
+		AfterEach(func() {
+			groupProcess.Signal(os.Kill)
+		})
+
+		It("becomes ready, and runs until it is signaled", func() {
+			Eventually(groupProcess.Ready()).Should(BeClosed())
+			Consistently(groupProcess.Wait()).ShouldNot(Receive())
+
+			groupProcess.Signal(os.Interrupt)
+			Eventually(groupProcess.Wait()).Should(Receive(BeNil()))
+		})
+	})
+
+	Describe("ReadyTimeout", func() {
+		BeforeEach(func() {
+			childRunner1 = fake_runner.NewTestRunner()
+			childRunner2 = fake_runner.NewTestRunner()
+
+			members = grouper.Members{
+				{Name: "child1", Runner: childRunner1},
+				{Name: "child2", Runner: childRunner2, ReadyTimeout: 50 * time.Millisecond},
+			}
+
+			groupProcess = ifrit.Background(grouper.NewOrdered(os.Interrupt, members))
+		})
+
+		AfterEach(func() {
+			childRunner1.EnsureExit()
+			childRunner2.EnsureExit()
+		})
+
+		It("stops a member which does not become ready in time, and reports it", func() {
+			signal1 := childRunner1.WaitForCall()
+			childRunner1.TriggerReady()
+			signal2 := childRunner2.WaitForCall()
+
+			Eventually(signal2).Should(Receive(Equal(os.Interrupt)))
+			childRunner2.TriggerExit(nil)
+
+			Eventually(signal1).Should(Receive(Equal(os.Interrupt)))
+			childRunner1.TriggerExit(nil)
+
+			var err error
+			Eventually(groupProcess.Wait()).Should(Receive(&err))
+			errTrace := err.(grouper.ErrorTrace)
+			Ω(errTrace).Should(ContainElement(matchExitEvent(members[1], grouper.ErrReadyTimeout{Name: "child2", Timeout: 50 * time.Millisecond})))
+			Ω(groupProcess.Ready()).ShouldNot(BeClosed())
+		})
+
+		It("kills and then abandons a member which ignores its signals", func() {
+			signal1 := childRunner1.WaitForCall()
+			childRunner1.TriggerReady()
+			signal2 := childRunner2.WaitForCall()
+
+			Eventually(signal2).Should(Receive(Equal(os.Interrupt)))
+			Eventually(signal2).Should(Receive(Equal(os.Kill)))
+
+			Eventually(signal1).Should(Receive(Equal(os.Interrupt)))
+			childRunner1.TriggerExit(nil)
+
+			var err error
+			Eventually(groupProcess.Wait()).Should(Receive(&err))
+			Ω(err).Should(ContainElement(matchExitEvent(members[1], grouper.ErrReadyTimeout{Name: "child2", Timeout: 50 * time.Millisecond})))
+		})
+	})
+
+	Describe("WithEscalation", func() {
+		BeforeEach(func() {
+			childRunner1 = fake_runner.NewTestRunner()
+			childRunner2 = fake_runner.NewTestRunner()
+
+			members = grouper.Members{
+				{Name: "child1", Runner: childRunner1},
+				{Name: "child2", Runner: childRunner2},
+			}
+
+			groupRunner = grouper.NewOrdered(os.Interrupt, members, grouper.WithEscalation(ifrit.Escalation{
+				{Grace: 20 * time.Millisecond},
+				{Signal: os.Kill, Grace: 20 * time.Millisecond},
+			}))
+			groupProcess = ifrit.Background(groupRunner)
+		})
+
+		AfterEach(func() {
+			childRunner1.EnsureExit()
+			childRunner2.EnsureExit()
+		})
+
+		It("escalates and then abandons a member which ignores its signals", func() {
+			signal1 := childRunner1.WaitForCall()
+			childRunner1.TriggerReady()
+			signal2 := childRunner2.WaitForCall()
+			childRunner2.TriggerReady()
+			Eventually(groupProcess.Ready()).Should(BeClosed())
+
+			groupProcess.Signal(syscall.SIGTERM)
+			Eventually(signal2).Should(Receive(Equal(syscall.SIGTERM)))
+			Eventually(signal2).Should(Receive(Equal(os.Kill)))
+
+			Eventually(signal1).Should(Receive(Equal(syscall.SIGTERM)))
+			childRunner1.TriggerExit(nil)
+
+			var err error
+			Eventually(groupProcess.Wait()).Should(Receive(&err))
+			Ω(err).Should(ContainElement(matchExitEvent(members[1], ifrit.ErrAbandoned)))
+		})
+	})
+
+	Describe("per-member shutdown", func() {
+		var signal1, signal2 <-chan os.Signal
+
+		BeforeEach(func() {
+			childRunner1 = fake_runner.NewTestRunner()
+			childRunner2 = fake_runner.NewTestRunner()
+		})
+
+		JustBeforeEach(func() {
+			groupProcess = ifrit.Background(groupRunner)
+
+			signal1 = childRunner1.WaitForCall()
+			childRunner1.TriggerReady()
+			signal2 = childRunner2.WaitForCall()
+			childRunner2.TriggerReady()
+			Eventually(groupProcess.Ready()).Should(BeClosed())
+		})
+
+		AfterEach(func() {
+			childRunner1.EnsureExit()
+			childRunner2.EnsureExit()
+		})
+
+		Context("when a member has a StopSignal", func() {
+			BeforeEach(func() {
+				members = grouper.Members{
+					{Name: "child1", Runner: childRunner1},
+					{Name: "child2", Runner: childRunner2, StopSignal: syscall.SIGQUIT},
+				}
+				groupRunner = grouper.NewOrdered(os.Interrupt, members)
+			})
+
+			It("sends the member its StopSignal instead of the group's signal", func() {
+				groupProcess.Signal(syscall.SIGTERM)
+				Eventually(signal2).Should(Receive(Equal(syscall.SIGQUIT)))
+				childRunner2.TriggerExit(nil)
+
+				Eventually(signal1).Should(Receive(Equal(syscall.SIGTERM)))
+				childRunner1.TriggerExit(nil)
+				Eventually(groupProcess.Wait()).Should(Receive(BeNil()))
+			})
+		})
+
+		Context("when a member has a StopTimeout and Escalation", func() {
+			BeforeEach(func() {
+				members = grouper.Members{
+					{Name: "child1", Runner: childRunner1},
+					{
+						Name:        "child2",
+						Runner:      childRunner2,
+						StopTimeout: 50 * time.Millisecond,
+						Escalation:  ifrit.Escalation{{Grace: 10 * time.Millisecond}, {Signal: os.Kill}},
+					},
+				}
+				groupRunner = grouper.NewOrdered(os.Interrupt, members)
+			})
+
+			It("escalates, then records the member as timed out and keeps stopping the group", func() {
+				groupProcess.Signal(syscall.SIGTERM)
+				Eventually(signal2).Should(Receive(Equal(syscall.SIGTERM)))
+				Eventually(signal2).Should(Receive(Equal(os.Kill)))
+
+				Eventually(signal1).Should(Receive(Equal(syscall.SIGTERM)))
+				childRunner1.TriggerExit(nil)
+
+				var err error
+				Eventually(groupProcess.Wait()).Should(Receive(&err))
+				Ω(err).Should(ContainElement(matchExitEvent(members[1], grouper.ErrStopTimeout{Name: "child2", Timeout: 50 * time.Millisecond})))
+			})
+		})
+
+		Context("WithShutdownBudget", func() {
+			BeforeEach(func() {
+				members = grouper.Members{
+					{Name: "child1", Runner: childRunner1},
+					{Name: "child2", Runner: childRunner2},
+				}
+				groupRunner = grouper.NewOrdered(os.Interrupt, members, grouper.WithShutdownBudget(50*time.Millisecond))
+			})
+
+			It("records every member which has not stopped in time as timed out", func() {
+				groupProcess.Signal(syscall.SIGTERM)
+				Eventually(signal2).Should(Receive(Equal(syscall.SIGTERM)))
+				Eventually(signal1).Should(Receive(Equal(syscall.SIGTERM)))
+
+				var err error
+				Eventually(groupProcess.Wait()).Should(Receive(&err))
+				Ω(err).Should(ConsistOf(
+					matchExitEvent(members[1], grouper.ErrStopTimeout{Name: "child2", Timeout: 50 * time.Millisecond}),
+					matchExitEvent(members[0], grouper.ErrStopTimeout{Name: "child1", Timeout: 50 * time.Millisecond}),
+				))
+			})
+		})
+	})
+
 	Describe("Stop", func() {
 
 		var runnerIndex int64
@@ -289,9 +497,9 @@ var _ = Describe("Ordered Group", func() {
 				r2, _ := makeRunner(30 * time.Millisecond)
 				r3, _ := makeRunner(50 * time.Millisecond)
 				members = grouper.Members{
-					{"child1", r1},
-					{"child2", r2},
-					{"child3", r3},
+					{Name: "child1", Runner: r1},
+					{Name: "child2", Runner: r2},
+					{Name: "child3", Runner: r3},
 				}
 			})
 
@@ -371,8 +579,8 @@ var _ = Describe("Ordered Group", func() {
 				r1 := makeSignalEchoRunner(200*time.Millisecond, "child1")
 				r2 := makeSignalEchoRunner(100*time.Millisecond, "child2")
 				members = grouper.Members{
-					{"child1", r1},
-					{"child2", r2},
+					{Name: "child1", Runner: r1},
+					{Name: "child2", Runner: r2},
 				}
 			})
 
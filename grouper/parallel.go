@@ -2,48 +2,45 @@ package grouper
 
 import (
 	"os"
-	"reflect"
-
-	"github.com/tedsuo/ifrit"
 )
 
 /*
 NewParallel starts it's members simultaneously.  Use a parallel group to describe a set
 of concurrent but independent processes.
+
+WithStartupWindow limits how many members start at once; the rest are started
+in order as earlier members become ready.
 */
-func NewParallel(terminationSignal os.Signal, members Members) ifrit.Runner {
-	return parallelGroup{
-		terminationSignal: terminationSignal,
-		pool:              make(map[string]ifrit.Process),
-		members:           members,
-	}
+func NewParallel(terminationSignal os.Signal, members Members, opts ...Option) StaticGroup {
+	return parallelGroup{newStaticGroup(terminationSignal, members, opts)}
 }
 
 type parallelGroup struct {
-	terminationSignal os.Signal
-	pool              map[string]ifrit.Process
-	members           Members
+	staticGroup
 }
 
 func (g parallelGroup) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	defer g.client.closeBroadcasters()
+
 	err := g.validate()
 	if err != nil {
 		return err
 	}
+	g.reset()
 
 	signal, errTrace := g.parallelStart(signals)
-	if errTrace != nil {
-		return g.stop(g.terminationSignal, signals, errTrace).ErrorOrNil()
+	if errTrace.stopsGroup() {
+		return g.stop(g.terminationSignal, shutdownCause(signals, errTrace), signals, errTrace).ErrorOrNil()
 	}
 
 	if signal != nil {
-		return g.stop(signal, signals, errTrace).ErrorOrNil()
+		return g.stop(signal, shutdownCause(signals, errTrace), signals, errTrace).ErrorOrNil()
 	}
 
 	close(ready)
 
 	signal, errTrace = g.waitForSignal(signals, errTrace)
-	return g.stop(signal, signals, errTrace).ErrorOrNil()
+	return g.stop(signal, shutdownCause(signals, errTrace), signals, errTrace).ErrorOrNil()
 }
 
 func (o parallelGroup) validate() error {
@@ -52,138 +49,94 @@ func (o parallelGroup) validate() error {
 
 func (g *parallelGroup) parallelStart(signals <-chan os.Signal) (os.Signal, ErrorTrace) {
 	numMembers := len(g.members)
-
-	cases := make([]reflect.SelectCase, 2*numMembers+1)
-
-	for i, member := range g.members {
-		process := ifrit.Background(member)
-
-		g.pool[member.Name] = process
-
-		cases[2*i] = reflect.SelectCase{
-			Dir:  reflect.SelectRecv,
-			Chan: reflect.ValueOf(process.Wait()),
-		}
-
-		cases[2*i+1] = reflect.SelectCase{
-			Dir:  reflect.SelectRecv,
-			Chan: reflect.ValueOf(process.Ready()),
+	numStarted := 0
+	numStarting := 0
+
+	// startWindow starts members in order until the startup window is full.
+	startWindow := func() {
+		for numStarted < numMembers && (g.startupWindow <= 0 || numStarting < g.startupWindow) {
+			g.startMember(signals, numStarted)
+			numStarted++
+			numStarting++
 		}
 	}
 
-	cases[2*numMembers] = reflect.SelectCase{
-		Dir:  reflect.SelectRecv,
-		Chan: reflect.ValueOf(signals),
+	numReady := 0
+	readyMembers := make([]bool, numMembers)
+
+	// markReady makes room in the startup window, and reports whether every
+	// member is ready.
+	markReady := func(i int) bool {
+		readyMembers[i] = true
+		numReady++
+		numStarting--
+		if numReady == numMembers {
+			return true
+		}
+		startWindow()
+		return false
 	}
 
-	numReady := 0
+	var errTrace ErrorTrace
+	startWindow()
 	for {
-		chosen, recv, _ := reflect.Select(cases)
-
-		switch {
-		case chosen == 2*numMembers:
-			return recv.Interface().(os.Signal), nil
-		case chosen%2 == 0:
-			recvError, _ := recv.Interface().(error)
-			return nil, ErrorTrace{ExitEvent{Member: g.members[chosen/2], Err: recvError}}
-		default:
-			cases[chosen].Chan = reflect.Zero(cases[chosen].Chan.Type())
-			numReady++
-			if numReady == numMembers {
-				return nil, nil
-			}
+		event, signal := g.nextEvent(signals)
+		if signal != nil {
+			return signal, errTrace
 		}
-	}
-}
 
-func (g *parallelGroup) waitForSignal(signals <-chan os.Signal, errTrace ErrorTrace) (os.Signal, ErrorTrace) {
-	cases := make([]reflect.SelectCase, 0, len(g.pool)+1)
-	for i := 0; i < len(g.pool); i++ {
-		cases = append(cases, reflect.SelectCase{
-			Dir:  reflect.SelectRecv,
-			Chan: reflect.ValueOf(g.pool[g.members[i].Name].Wait()),
-		})
-	}
-	cases = append(cases, reflect.SelectCase{
-		Dir:  reflect.SelectRecv,
-		Chan: reflect.ValueOf(signals),
-	})
-
-	chosen, recv, _ := reflect.Select(cases)
-	if chosen == len(cases)-1 {
-		return recv.Interface().(os.Signal), errTrace
-	}
+		if event.kind == eventReady {
+			g.memberReady(g.members[event.index])
+			if markReady(event.index) {
+				return nil, errTrace
+			}
+			continue
+		}
 
-	var err error
-	if !recv.IsNil() {
-		err = recv.Interface().(error)
+		var exit ExitEvent
+		errTrace, exit = g.recordExit(errTrace, event)
+		if !exit.Member.tolerates(exit.Err) {
+			return nil, errTrace
+		}
+		if !readyMembers[event.index] && markReady(event.index) {
+			return nil, errTrace
+		}
 	}
-
-	errTrace = append(errTrace, ExitEvent{
-		Member: g.members[chosen],
-		Err:    err,
-	})
-
-	return g.terminationSignal, errTrace
 }
 
-func (g *parallelGroup) stop(signal os.Signal, signals <-chan os.Signal, errTrace ErrorTrace) ErrorTrace {
+func (g *parallelGroup) stop(signal os.Signal, cause error, signals <-chan os.Signal, errTrace ErrorTrace) ErrorTrace {
+	deadline := g.shutdownDeadline()
 	errOccurred := false
-	exited := map[string]struct{}{}
-	if len(errTrace) > 0 {
-		for _, exitEvent := range errTrace {
-			exited[exitEvent.Member.Name] = struct{}{}
-			if exitEvent.Err != nil {
-				errOccurred = true
-			}
+	for _, exitEvent := range errTrace {
+		if exitEvent.Err != nil {
+			errOccurred = true
 		}
 	}
 
-	cases := make([]reflect.SelectCase, 0, len(g.members))
-	liveMembers := make([]Member, 0, len(g.members))
-	for _, member := range g.members {
-		if _, found := exited[member.Name]; found {
+	stopped := make(chan memberStop, len(g.members))
+	stopping := make([]int, 0, len(g.members))
+	for i, member := range g.members {
+		if _, started := g.pool[member.Name]; !started || g.exited[member.Name] {
 			continue
 		}
-
-		process := g.pool[member.Name]
-
-		process.Signal(signal)
-
-		cases = append(cases, reflect.SelectCase{
-			Dir:  reflect.SelectRecv,
-			Chan: reflect.ValueOf(process.Wait()),
-		})
-
-		liveMembers = append(liveMembers, member)
+		g.stopInBackground(i, signal, cause, deadline, stopped)
+		stopping = append(stopping, i)
 	}
 
-	cases = append(cases, reflect.SelectCase{
-		Dir:  reflect.SelectRecv,
-		Chan: reflect.ValueOf(signals),
-	})
-
-	// account for the signals channel
-	for numExited := 1; numExited < len(cases); numExited++ {
-		chosen, recv, _ := reflect.Select(cases)
-		cases[chosen].Chan = reflect.Zero(cases[chosen].Chan.Type())
-		recvError, _ := recv.Interface().(error)
-
-		if chosen == len(cases)-1 {
-			signal = recv.Interface().(os.Signal)
-			for _, member := range liveMembers {
-				g.pool[member.Name].Signal(signal)
+	for numStopping := len(stopping); numStopping > 0; {
+		select {
+		case stop := <-stopped:
+			numStopping--
+			member := g.members[stop.index]
+			errTrace = g.memberExited(errTrace, newExitEvent(member, g.pool[member.Name], stop.err))
+			if stop.err != nil {
+				errOccurred = true
 			}
-			continue
-		}
-
-		errTrace = append(errTrace, ExitEvent{
-			Member: liveMembers[chosen],
-			Err:    recvError,
-		})
 
-		if recvError != nil {
-			errOccurred = true
+		case signal = <-signals:
+			for _, i := range stopping {
+				g.pool[g.members[i].Name].Signal(signal)
+			}
 		}
 	}
 
@@ -10,6 +10,7 @@ import (
 	"github.com/tedsuo/ifrit/fake_runner"
 	"github.com/tedsuo/ifrit/ginkgomon"
 	"github.com/tedsuo/ifrit/grouper"
+	"github.com/tedsuo/ifrit/test_helpers"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -34,9 +35,9 @@ var _ = Describe("Parallel Group", func() {
 		childRunner3 = fake_runner.NewTestRunner()
 
 		members = grouper.Members{
-			{"child1", childRunner1},
-			{"child2", childRunner2},
-			{"child3", childRunner3},
+			{Name: "child1", Runner: childRunner1},
+			{Name: "child2", Runner: childRunner2},
+			{Name: "child3", Runner: childRunner3},
 		}
 
 		groupRunner = grouper.NewParallel(os.Interrupt, members)
@@ -151,9 +152,9 @@ var _ = Describe("Parallel Group", func() {
 						var err error
 						Eventually(groupProcess.Wait()).Should(Receive(&err))
 						Ω(err).Should(ConsistOf(
-							grouper.ExitEvent{grouper.Member{"child1", childRunner1}, nil},
-							grouper.ExitEvent{grouper.Member{"child2", childRunner2}, errors.New("Fail")},
-							grouper.ExitEvent{grouper.Member{"child3", childRunner3}, nil},
+							matchExitEvent(grouper.Member{Name: "child1", Runner: childRunner1}, nil),
+							matchExitEvent(grouper.Member{Name: "child2", Runner: childRunner2}, errors.New("Fail")),
+							matchExitEvent(grouper.Member{Name: "child3", Runner: childRunner3}, nil),
 						))
 					})
 				})
@@ -184,9 +185,9 @@ var _ = Describe("Parallel Group", func() {
 
 					Eventually(groupProcess.Wait()).Should(Receive(&err))
 					Ω(err).Should(ConsistOf(
-						grouper.ExitEvent{grouper.Member{"child2", childRunner2}, errors.New("Fail")},
-						grouper.ExitEvent{grouper.Member{"child1", childRunner1}, nil},
-						grouper.ExitEvent{grouper.Member{"child3", childRunner3}, nil},
+						matchExitEvent(grouper.Member{Name: "child2", Runner: childRunner2}, errors.New("Fail")),
+						matchExitEvent(grouper.Member{Name: "child1", Runner: childRunner1}, nil),
+						matchExitEvent(grouper.Member{Name: "child3", Runner: childRunner3}, nil),
 					))
 				})
 			})
@@ -206,12 +207,191 @@ var _ = Describe("Parallel Group", func() {
 					Eventually(groupProcess.Wait()).Should(Receive(&err))
 
 					Ω(err).Should(ConsistOf(
-						grouper.ExitEvent{grouper.Member{"child1", childRunner1}, errors.New("Fail")},
-						grouper.ExitEvent{grouper.Member{"child2", childRunner2}, nil},
-						grouper.ExitEvent{grouper.Member{"child3", childRunner3}, nil},
+						matchExitEvent(grouper.Member{Name: "child1", Runner: childRunner1}, errors.New("Fail")),
+						matchExitEvent(grouper.Member{Name: "child2", Runner: childRunner2}, nil),
+						matchExitEvent(grouper.Member{Name: "child3", Runner: childRunner3}, nil),
 					))
 				})
 			})
 		})
 	})
+
+	Describe("ReadyTimeout", func() {
+		BeforeEach(func() {
+			members[1].ReadyTimeout = 50 * time.Millisecond
+			groupProcess = ifrit.Background(grouper.NewParallel(os.Interrupt, members))
+		})
+
+		It("stops a member which does not become ready in time, then the rest of the group", func() {
+			signal1 := childRunner1.WaitForCall()
+			childRunner1.TriggerReady()
+			signal3 := childRunner3.WaitForCall()
+			childRunner3.TriggerReady()
+
+			signal2 := childRunner2.WaitForCall()
+			Eventually(signal2).Should(Receive(Equal(os.Interrupt)))
+			childRunner2.TriggerExit(nil)
+
+			Eventually(signal1).Should(Receive(Equal(os.Interrupt)))
+			Eventually(signal3).Should(Receive(Equal(os.Interrupt)))
+			childRunner1.TriggerExit(nil)
+			childRunner3.TriggerExit(nil)
+
+			var err error
+			Eventually(groupProcess.Wait()).Should(Receive(&err))
+			Ω(err).Should(ContainElement(matchExitEvent(members[1], grouper.ErrReadyTimeout{Name: "child2", Timeout: 50 * time.Millisecond})))
+		})
+
+		It("does not enforce the timeout on a member the group is already stopping", func() {
+			signal1 := childRunner1.WaitForCall()
+			signal2 := childRunner2.WaitForCall()
+			signal3 := childRunner3.WaitForCall()
+
+			groupProcess.Signal(syscall.SIGTERM)
+			Eventually(signal2).Should(Receive(Equal(syscall.SIGTERM)))
+			Consistently(signal2, 150*time.Millisecond).ShouldNot(Receive())
+
+			Eventually(signal1).Should(Receive(Equal(syscall.SIGTERM)))
+			Eventually(signal3).Should(Receive(Equal(syscall.SIGTERM)))
+			childRunner1.TriggerExit(nil)
+			childRunner2.TriggerExit(nil)
+			childRunner3.TriggerExit(nil)
+			Eventually(groupProcess.Wait()).Should(Receive(BeNil()))
+		})
+	})
+	Describe("when a member panics", func() {
+		BeforeEach(func() {
+			members[1].Runner = ifrit.RunFunc(func(signals <-chan os.Signal, ready chan<- struct{}) error {
+				panic("boom")
+			})
+			groupProcess = ifrit.Background(grouper.NewParallel(os.Interrupt, members))
+		})
+
+		It("treats the panic as a failed exit and stops the rest of the group", func() {
+			signal1 := childRunner1.WaitForCall()
+			signal3 := childRunner3.WaitForCall()
+
+			Eventually(signal1).Should(Receive(Equal(os.Interrupt)))
+			Eventually(signal3).Should(Receive(Equal(os.Interrupt)))
+			childRunner1.TriggerExit(nil)
+			childRunner3.TriggerExit(nil)
+
+			var err error
+			Eventually(groupProcess.Wait()).Should(Receive(&err))
+			errTrace := err.(grouper.ErrorTrace)
+			Ω(errTrace[0].Member.Name).Should(Equal("child2"))
+			Ω(errTrace[0].Err).Should(BeAssignableToTypeOf(ifrit.PanicError{}))
+		})
+	})
+	Describe("observers", func() {
+		It("are inherited by the members of the group", func() {
+			observer := new(test_helpers.ObserverRecorder)
+			groupProcess = ifrit.Background(groupRunner, ifrit.WithObserver(observer), ifrit.WithName("group"))
+
+			childRunner1.TriggerReady()
+			childRunner2.TriggerReady()
+			childRunner3.TriggerReady()
+			Eventually(groupProcess.Ready()).Should(BeClosed())
+
+			Ω(observer.Events()).Should(ContainElement("start group/child1"))
+			Ω(observer.Events()).Should(ContainElement("ready group/child2"))
+			Ω(observer.Events()).Should(ContainElement("ready group"))
+		})
+	})
+	Describe("shutdown causes", func() {
+		var signal2, signal3 <-chan os.Signal
+
+		BeforeEach(func() {
+			groupProcess = ifrit.Background(groupRunner)
+
+			childRunner1.WaitForCall()
+			childRunner1.TriggerReady()
+			signal2 = childRunner2.WaitForCall()
+			childRunner2.TriggerReady()
+			signal3 = childRunner3.WaitForCall()
+			childRunner3.TriggerReady()
+			Eventually(groupProcess.Ready()).Should(BeClosed())
+		})
+
+		It("tells the other members which member exited", func() {
+			childRunner1.TriggerExit(errors.New("Fail"))
+			Eventually(signal2).Should(Receive())
+			Eventually(signal3).Should(Receive())
+			childRunner2.TriggerExit(nil)
+			childRunner3.TriggerExit(nil)
+
+			var err error
+			Eventually(groupProcess.Wait()).Should(Receive(&err))
+			errTrace := err.(grouper.ErrorTrace)
+			Ω(errTrace).Should(HaveLen(3))
+			Ω(errTrace[0].Cause).Should(BeNil())
+
+			memberExited := grouper.ErrMemberExited{Name: "child1", Err: errors.New("Fail")}
+			Ω(errTrace[1].Cause).Should(Equal(memberExited))
+			Ω(errTrace[2].Cause).Should(Equal(memberExited))
+		})
+
+		It("passes along the cause the group was signaled with", func() {
+			cause := errors.New("operator requested shutdown")
+			ifrit.SignalWithCause(groupProcess, os.Interrupt, cause)
+			Eventually(signal2).Should(Receive())
+			Eventually(signal3).Should(Receive())
+
+			childRunner1.TriggerExit(errors.New("Fail"))
+			childRunner2.TriggerExit(nil)
+			childRunner3.TriggerExit(nil)
+
+			var err error
+			Eventually(groupProcess.Wait()).Should(Receive(&err))
+			for _, exit := range err.(grouper.ErrorTrace) {
+				Ω(exit.Cause).Should(Equal(cause))
+			}
+		})
+	})
+
+	Describe("startup window", func() {
+		var signal1, signal2 <-chan os.Signal
+
+		JustBeforeEach(func() {
+			groupRunner = grouper.NewParallel(os.Interrupt, members, grouper.WithStartupWindow(2))
+			groupProcess = ifrit.Background(groupRunner)
+
+			signal1 = childRunner1.WaitForCall()
+			signal2 = childRunner2.WaitForCall()
+		})
+
+		It("only starts as many members as the window allows", func() {
+			Consistently(childRunner3.RunCallCount).Should(Equal(0))
+
+			childRunner1.TriggerReady()
+			Eventually(childRunner3.RunCallCount).Should(Equal(1))
+			Consistently(groupProcess.Ready()).ShouldNot(BeClosed())
+
+			childRunner2.TriggerReady()
+			childRunner3.TriggerReady()
+			Eventually(groupProcess.Ready()).Should(BeClosed())
+		})
+
+		Context("when a tolerated member exits before it is ready", func() {
+			BeforeEach(func() {
+				members[0].Policy = grouper.OneShot
+			})
+
+			It("starts the next member in its place", func() {
+				childRunner1.TriggerExit(nil)
+				Eventually(childRunner3.RunCallCount).Should(Equal(1))
+			})
+		})
+
+		It("only stops the members which have started", func() {
+			groupProcess.Signal(syscall.SIGTERM)
+			Eventually(signal1).Should(Receive(Equal(syscall.SIGTERM)))
+			Eventually(signal2).Should(Receive(Equal(syscall.SIGTERM)))
+
+			childRunner1.TriggerExit(nil)
+			childRunner2.TriggerExit(nil)
+			Eventually(groupProcess.Wait()).Should(Receive(BeNil()))
+			Ω(childRunner3.RunCallCount()).Should(Equal(0))
+		})
+	})
 })
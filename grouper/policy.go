@@ -0,0 +1,50 @@
+package grouper
+
+/*
+A Policy decides how a static group reacts when a member exits.
+*/
+type Policy int
+
+const (
+	// Critical members stop the group when they exit.  This is the default.
+	Critical Policy = iota
+
+	// Optional members, such as best-effort sidecars, may exit at any time.
+	// Their exit is recorded in the group's ErrorTrace, but the group keeps
+	// running.
+	Optional
+
+	// OneShot members, such as migrations, are expected to exit successfully.
+	// A successful exit counts as becoming ready, and does not stop the group;
+	// exiting with an error does.
+	OneShot
+)
+
+// tolerates reports whether the group keeps running after the member exits
+// with err.
+func (m Member) tolerates(err error) bool {
+	switch m.Policy {
+	case Optional:
+		return true
+	case OneShot:
+		return err == nil
+	default:
+		return false
+	}
+}
+
+// stopsGroup reports whether the trace contains an exit which the exiting
+// member's Policy does not tolerate.
+func (trace ErrorTrace) stopsGroup() bool {
+	_, found := trace.firstIntolerable()
+	return found
+}
+
+func (trace ErrorTrace) firstIntolerable() (ExitEvent, bool) {
+	for _, exit := range trace {
+		if !exit.Member.tolerates(exit.Err) {
+			return exit, true
+		}
+	}
+	return ExitEvent{}, false
+}
@@ -0,0 +1,157 @@
+package grouper_test
+
+import (
+	"errors"
+	"os"
+
+	"github.com/tedsuo/ifrit"
+	"github.com/tedsuo/ifrit/fake_runner"
+	"github.com/tedsuo/ifrit/ginkgomon"
+	"github.com/tedsuo/ifrit/grouper"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Member policies", func() {
+	var (
+		groupProcess ifrit.Process
+
+		serverRunner  *fake_runner.TestRunner
+		sidecarRunner *fake_runner.TestRunner
+		migrateRunner *fake_runner.TestRunner
+
+		server  grouper.Member
+		sidecar grouper.Member
+		migrate grouper.Member
+	)
+
+	BeforeEach(func() {
+		serverRunner = fake_runner.NewTestRunner()
+		sidecarRunner = fake_runner.NewTestRunner()
+		migrateRunner = fake_runner.NewTestRunner()
+
+		server = grouper.Member{Name: "server", Runner: serverRunner}
+		sidecar = grouper.Member{Name: "sidecar", Runner: sidecarRunner, Policy: grouper.Optional}
+		migrate = grouper.Member{Name: "migrate", Runner: migrateRunner, Policy: grouper.OneShot}
+	})
+
+	AfterEach(func() {
+		serverRunner.EnsureExit()
+		sidecarRunner.EnsureExit()
+		migrateRunner.EnsureExit()
+
+		ginkgomon.Kill(groupProcess)
+	})
+
+	Describe("in a parallel group", func() {
+		var serverSignals <-chan os.Signal
+
+		BeforeEach(func() {
+			groupProcess = ifrit.Background(grouper.NewParallel(os.Interrupt, grouper.Members{server, sidecar, migrate}))
+			serverSignals = serverRunner.WaitForCall()
+			serverRunner.TriggerReady()
+			sidecarRunner.TriggerReady()
+		})
+
+		It("counts a successful one-shot exit as ready", func() {
+			Consistently(groupProcess.Ready()).ShouldNot(BeClosed())
+			migrateRunner.TriggerExit(nil)
+			Eventually(groupProcess.Ready()).Should(BeClosed())
+			Consistently(serverSignals).ShouldNot(Receive())
+		})
+
+		It("stops when a one-shot member fails", func() {
+			migrateRunner.TriggerExit(errors.New("Fail"))
+			Eventually(serverSignals).Should(Receive(Equal(os.Interrupt)))
+			serverRunner.TriggerExit(nil)
+			sidecarRunner.TriggerExit(nil)
+
+			var err error
+			Eventually(groupProcess.Wait()).Should(Receive(&err))
+			Ω(err).Should(ContainElement(matchExitEvent(migrate, errors.New("Fail"))))
+		})
+
+		Context("once the group is ready", func() {
+			BeforeEach(func() {
+				migrateRunner.TriggerExit(nil)
+				Eventually(groupProcess.Ready()).Should(BeClosed())
+			})
+
+			It("keeps running when an optional member fails, and records its exit", func() {
+				sidecarRunner.TriggerExit(errors.New("Fail"))
+				Consistently(serverSignals).ShouldNot(Receive())
+				Consistently(groupProcess.Wait()).ShouldNot(Receive())
+
+				groupProcess.Signal(os.Interrupt)
+				Eventually(serverSignals).Should(Receive(Equal(os.Interrupt)))
+				serverRunner.TriggerExit(nil)
+
+				var err error
+				Eventually(groupProcess.Wait()).Should(Receive(&err))
+				Ω(err).Should(ConsistOf(
+					matchExitEvent(migrate, nil),
+					matchExitEvent(sidecar, errors.New("Fail")),
+					matchExitEvent(server, nil),
+				))
+			})
+
+			It("stops when a critical member exits", func() {
+				serverRunner.TriggerExit(nil)
+				Eventually(sidecarRunner.WaitForCall()).Should(Receive(Equal(os.Interrupt)))
+				sidecarRunner.TriggerExit(nil)
+				Eventually(groupProcess.Wait()).Should(Receive(BeNil()))
+			})
+		})
+	})
+
+	Describe("in an ordered group", func() {
+		BeforeEach(func() {
+			groupProcess = ifrit.Background(grouper.NewOrdered(os.Interrupt, grouper.Members{migrate, sidecar, server}))
+		})
+
+		It("starts the next member once a one-shot member succeeds", func() {
+			Eventually(migrateRunner.RunCallCount).Should(Equal(1))
+			Consistently(sidecarRunner.RunCallCount).Should(Equal(0))
+
+			migrateRunner.TriggerExit(nil)
+			Eventually(sidecarRunner.RunCallCount).Should(Equal(1))
+			sidecarRunner.TriggerReady()
+			Eventually(serverRunner.RunCallCount).Should(Equal(1))
+		})
+
+		It("starts the next member when an optional member exits before it is ready", func() {
+			migrateRunner.TriggerExit(nil)
+			sidecarRunner.TriggerExit(errors.New("Fail"))
+
+			serverRunner.TriggerReady()
+			Eventually(groupProcess.Ready()).Should(BeClosed())
+		})
+
+		It("exits once every member has exited", func() {
+			migrateRunner.TriggerExit(nil)
+			sidecarRunner.TriggerExit(nil)
+			serverRunner.TriggerReady()
+			Eventually(groupProcess.Ready()).Should(BeClosed())
+
+			serverRunner.TriggerExit(nil)
+			Eventually(groupProcess.Wait()).Should(Receive(BeNil()))
+		})
+	})
+
+	Describe("in a DAG group", func() {
+		BeforeEach(func() {
+			server.DependsOn = []string{"migrate"}
+			groupProcess = ifrit.Background(grouper.NewDAG(os.Interrupt, grouper.Members{server, migrate}))
+		})
+
+		It("starts dependents once a one-shot member succeeds", func() {
+			Eventually(migrateRunner.RunCallCount).Should(Equal(1))
+			Consistently(serverRunner.RunCallCount).Should(Equal(0))
+
+			migrateRunner.TriggerExit(nil)
+			serverRunner.TriggerReady()
+			Eventually(groupProcess.Ready()).Should(BeClosed())
+		})
+	})
+})
@@ -37,9 +37,9 @@ var _ = Describe("QueueQueued", func() {
 			childRunner3 = fake_runner.NewTestRunner()
 
 			members = grouper.Members{
-				{"child1", childRunner1},
-				{"child2", childRunner2},
-				{"child3", childRunner3},
+				{Name: "child1", Runner: childRunner1},
+				{Name: "child2", Runner: childRunner2},
+				{Name: "child3", Runner: childRunner3},
 			}
 
 			groupRunner = grouper.NewQueueOrdered(os.Interrupt, members)
@@ -165,9 +165,9 @@ var _ = Describe("QueueQueued", func() {
 						errTrace := err.(grouper.ErrorTrace)
 						Ω(errTrace).Should(HaveLen(3))
 
-						Ω(errTrace).Should(ContainElement(grouper.ExitEvent{grouper.Member{"child1", childRunner1}, nil}))
-						Ω(errTrace).Should(ContainElement(grouper.ExitEvent{grouper.Member{"child2", childRunner2}, errors.New("Fail")}))
-						Ω(errTrace).Should(ContainElement(grouper.ExitEvent{grouper.Member{"child3", childRunner3}, nil}))
+						Ω(errTrace).Should(ContainElement(matchExitEvent(grouper.Member{Name: "child1", Runner: childRunner1}, nil)))
+						Ω(errTrace).Should(ContainElement(matchExitEvent(grouper.Member{Name: "child2", Runner: childRunner2}, errors.New("Fail"))))
+						Ω(errTrace).Should(ContainElement(matchExitEvent(grouper.Member{Name: "child3", Runner: childRunner3}, nil)))
 					})
 				})
 			})
@@ -227,8 +227,8 @@ var _ = Describe("QueueQueued", func() {
 
 				Eventually(groupProcess.Wait()).Should(Receive(&err))
 				errTrace := err.(grouper.ErrorTrace)
-				Ω(errTrace).Should(ContainElement(grouper.ExitEvent{grouper.Member{"child1", childRunner1}, nil}))
-				Ω(errTrace).Should(ContainElement(grouper.ExitEvent{grouper.Member{"child2", childRunner2}, errors.New("Fail")}))
+				Ω(errTrace).Should(ContainElement(matchExitEvent(grouper.Member{Name: "child1", Runner: childRunner1}, nil)))
+				Ω(errTrace).Should(ContainElement(matchExitEvent(grouper.Member{Name: "child2", Runner: childRunner2}, errors.New("Fail"))))
 				Ω(exitIndex("child1", errTrace)).Should(BeNumerically(">", exitIndex("child2", errTrace)))
 			})
 		})
@@ -289,9 +289,9 @@ var _ = Describe("QueueQueued", func() {
 				r2, _ := makeRunner(30 * time.Millisecond)
 				r3, _ := makeRunner(50 * time.Millisecond)
 				members = grouper.Members{
-					{"child1", r1},
-					{"child2", r2},
-					{"child3", r3},
+					{Name: "child1", Runner: r1},
+					{Name: "child2", Runner: r2},
+					{Name: "child3", Runner: r3},
 				}
 			})
 
@@ -380,8 +380,8 @@ var _ = Describe("QueueQueued", func() {
 				r1 := makeSignalEchoRunner(100*time.Millisecond, "child1")
 				r2 := makeSignalEchoRunner(200*time.Millisecond, "child2")
 				members = grouper.Members{
-					{"child1", r1},
-					{"child2", r2},
+					{Name: "child1", Runner: r1},
+					{Name: "child2", Runner: r2},
 				}
 			})
 
@@ -0,0 +1,110 @@
+package grouper
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/tedsuo/ifrit"
+)
+
+/*
+NewQuorum starts its members simultaneously, and becomes ready once quorum of
+them are ready.  Members may exit without stopping the group, as long as at
+least quorum members are still running; once fewer remain, the group sends the
+rest its termination signal with an ErrQuorumLost cause.  Use a quorum group to
+run replicas which can tolerate losing some of their number.
+
+Every exit is recorded in the group's ErrorTrace.  Member Policies are ignored,
+since the quorum decides when the group stops.
+*/
+func NewQuorum(terminationSignal os.Signal, quorum int, members Members, opts ...Option) StaticGroup {
+	return &quorumGroup{
+		parallelGroup: parallelGroup{newStaticGroup(terminationSignal, members, opts)},
+		quorum:        quorum,
+	}
+}
+
+type quorumGroup struct {
+	parallelGroup
+	quorum int
+}
+
+/*
+ErrInvalidQuorum is returned by a quorum group whose quorum is less than one,
+or greater than its number of members.
+*/
+type ErrInvalidQuorum struct {
+	Quorum  int
+	Members int
+}
+
+func (e ErrInvalidQuorum) Error() string {
+	return fmt.Sprintf("invalid quorum of %d for %d members", e.Quorum, e.Members)
+}
+
+/*
+ErrQuorumLost is the cause given to the remaining members of a quorum group
+once too few members are running for it to continue.
+*/
+type ErrQuorumLost struct {
+	Quorum  int
+	Running int
+}
+
+func (e ErrQuorumLost) Error() string {
+	return fmt.Sprintf("quorum of %d lost with %d members running", e.Quorum, e.Running)
+}
+
+func (g *quorumGroup) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	defer g.client.closeBroadcasters()
+
+	err := g.validate()
+	if err != nil {
+		return err
+	}
+	g.reset()
+
+	for i := range g.members {
+		g.startMember(signals, i)
+	}
+
+	numReady := 0
+	readyMembers := make([]bool, len(g.members))
+
+	var errTrace ErrorTrace
+	for {
+		event, signal := g.nextEvent(signals)
+		if signal != nil {
+			return g.stop(signal, ifrit.Cause(signals), signals, errTrace).ErrorOrNil()
+		}
+
+		if event.kind == eventReady {
+			g.memberReady(g.members[event.index])
+			readyMembers[event.index] = true
+			numReady++
+			if numReady == g.quorum && ready != nil {
+				close(ready)
+				ready = nil
+			}
+			continue
+		}
+
+		errTrace, _ = g.recordExit(errTrace, event)
+		if readyMembers[event.index] {
+			numReady--
+		}
+
+		running := len(g.pool) - len(g.exited)
+		if running < g.quorum {
+			cause := ErrQuorumLost{Quorum: g.quorum, Running: running}
+			return g.stop(g.terminationSignal, cause, signals, errTrace).ErrorOrNil()
+		}
+	}
+}
+
+func (g *quorumGroup) validate() error {
+	if g.quorum < 1 || g.quorum > len(g.members) {
+		return ErrInvalidQuorum{Quorum: g.quorum, Members: len(g.members)}
+	}
+	return g.members.Validate()
+}
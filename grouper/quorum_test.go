@@ -0,0 +1,125 @@
+package grouper_test
+
+import (
+	"errors"
+	"os"
+
+	"github.com/tedsuo/ifrit"
+	"github.com/tedsuo/ifrit/fake_runner"
+	"github.com/tedsuo/ifrit/ginkgomon"
+	"github.com/tedsuo/ifrit/grouper"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Quorum Group", func() {
+	var (
+		groupProcess ifrit.Process
+		members      grouper.Members
+
+		childRunner1 *fake_runner.TestRunner
+		childRunner2 *fake_runner.TestRunner
+		childRunner3 *fake_runner.TestRunner
+	)
+
+	BeforeEach(func() {
+		childRunner1 = fake_runner.NewTestRunner()
+		childRunner2 = fake_runner.NewTestRunner()
+		childRunner3 = fake_runner.NewTestRunner()
+
+		members = grouper.Members{
+			{Name: "child1", Runner: childRunner1},
+			{Name: "child2", Runner: childRunner2},
+			{Name: "child3", Runner: childRunner3},
+		}
+	})
+
+	AfterEach(func() {
+		childRunner1.EnsureExit()
+		childRunner2.EnsureExit()
+		childRunner3.EnsureExit()
+
+		ginkgomon.Kill(groupProcess)
+	})
+
+	Context("with a quorum of two", func() {
+		var signals1, signals2, signals3 <-chan os.Signal
+
+		BeforeEach(func() {
+			groupProcess = ifrit.Background(grouper.NewQuorum(os.Interrupt, 2, members))
+
+			signals1 = childRunner1.WaitForCall()
+			signals2 = childRunner2.WaitForCall()
+			signals3 = childRunner3.WaitForCall()
+		})
+
+		It("becomes ready once two members are ready", func() {
+			childRunner1.TriggerReady()
+			Consistently(groupProcess.Ready()).ShouldNot(BeClosed())
+
+			childRunner3.TriggerReady()
+			Eventually(groupProcess.Ready()).Should(BeClosed())
+		})
+
+		It("becomes ready when a member fails before the quorum is ready", func() {
+			childRunner1.TriggerExit(errors.New("Fail"))
+			childRunner2.TriggerReady()
+			childRunner3.TriggerReady()
+			Eventually(groupProcess.Ready()).Should(BeClosed())
+			Consistently(signals2).ShouldNot(Receive())
+		})
+
+		Context("once it is ready", func() {
+			BeforeEach(func() {
+				childRunner1.TriggerReady()
+				childRunner2.TriggerReady()
+				childRunner3.TriggerReady()
+				Eventually(groupProcess.Ready()).Should(BeClosed())
+			})
+
+			It("keeps running while a quorum of members is running", func() {
+				childRunner1.TriggerExit(errors.New("Fail"))
+				Consistently(signals2).ShouldNot(Receive())
+				Consistently(signals3).ShouldNot(Receive())
+				Consistently(groupProcess.Wait()).ShouldNot(Receive())
+			})
+
+			It("stops the remaining members once the quorum is lost", func() {
+				childRunner1.TriggerExit(errors.New("Fail"))
+				childRunner2.TriggerExit(nil)
+				Eventually(signals3).Should(Receive(Equal(os.Interrupt)))
+				childRunner3.TriggerExit(nil)
+
+				var err error
+				Eventually(groupProcess.Wait()).Should(Receive(&err))
+				errTrace := err.(grouper.ErrorTrace)
+				Ω(errTrace).Should(HaveLen(3))
+				Ω(errTrace[:2]).Should(ConsistOf(
+					matchExitEvent(members[0], errors.New("Fail")),
+					matchExitEvent(members[1], nil),
+				))
+				Ω(errTrace[2]).Should(matchExitEvent(members[2], nil))
+				Ω(errTrace[2].Cause).Should(Equal(grouper.ErrQuorumLost{Quorum: 2, Running: 1}))
+			})
+
+			It("stops every member when it is signaled", func() {
+				groupProcess.Signal(os.Interrupt)
+				Eventually(signals1).Should(Receive(Equal(os.Interrupt)))
+				Eventually(signals2).Should(Receive(Equal(os.Interrupt)))
+				Eventually(signals3).Should(Receive(Equal(os.Interrupt)))
+
+				childRunner1.TriggerExit(nil)
+				childRunner2.TriggerExit(nil)
+				childRunner3.TriggerExit(nil)
+				Eventually(groupProcess.Wait()).Should(Receive(BeNil()))
+			})
+		})
+	})
+
+	It("fails to run with a quorum larger than its members", func() {
+		groupProcess = ifrit.Background(grouper.NewQuorum(os.Interrupt, 4, members))
+		Eventually(groupProcess.Wait()).Should(Receive(Equal(grouper.ErrInvalidQuorum{Quorum: 4, Members: 3})))
+		Ω(childRunner1.RunCallCount()).Should(Equal(0))
+	})
+})
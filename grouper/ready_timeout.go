@@ -0,0 +1,68 @@
+package grouper
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/tedsuo/ifrit"
+)
+
+/*
+ErrReadyTimeout is recorded in a group's ErrorTrace when a member does not
+become ready within its ReadyTimeout. The member is sent the group's
+termination signal (or os.Interrupt, if there is none), is killed if it has
+not exited after a further ReadyTimeout, and is abandoned if it has still not
+exited after another.
+*/
+type ErrReadyTimeout struct {
+	Name    string
+	Timeout time.Duration
+}
+
+func (e ErrReadyTimeout) Error() string {
+	return fmt.Sprintf("member %s was not ready after %s", e.Name, e.Timeout)
+}
+
+type readyTimer struct {
+	timer *time.Timer
+}
+
+func startReadyTimer(member Member) readyTimer {
+	if member.ReadyTimeout <= 0 {
+		return readyTimer{}
+	}
+	return readyTimer{time.NewTimer(member.ReadyTimeout)}
+}
+
+// C returns the timer's channel, or nil if the member has no ReadyTimeout.
+func (t readyTimer) C() <-chan time.Time {
+	if t.timer == nil {
+		return nil
+	}
+	return t.timer.C
+}
+
+func (t readyTimer) Stop() {
+	if t.timer != nil {
+		t.timer.Stop()
+	}
+}
+
+// stopUnready stops a member which did not become ready in time.  It may take
+// two further ReadyTimeouts before abandoning a member which ignores its
+// signals, so it must not be called from a group's own goroutine.
+func stopUnready(member Member, process ifrit.Process, signal os.Signal) ExitEvent {
+	if signal == nil {
+		signal = os.Interrupt
+	}
+	timeoutErr := ErrReadyTimeout{Name: member.Name, Timeout: member.ReadyTimeout}
+	ifrit.SignalWithCause(process, signal, timeoutErr)
+
+	ifrit.Escalation{
+		{Grace: member.ReadyTimeout},
+		{Signal: os.Kill, Grace: member.ReadyTimeout},
+	}.Stop(process)
+
+	return newExitEvent(member, process, timeoutErr)
+}
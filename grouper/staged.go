@@ -0,0 +1,42 @@
+package grouper
+
+import "os"
+
+/*
+NewStaged starts each stage's members in parallel, once every member of the
+previous stage is ready.  On shutdown, stages are stopped in reverse, each
+stage once the stages after it have exited.  Use a staged group in place of an
+ordered group of parallel groups, such as "infrastructure, then services, then
+ingress".
+
+The members of every stage form a single group, so member names must be unique
+across stages, and the ErrorTrace is flat.  Each ExitEvent records the stage of
+its member, numbered from 1.
+*/
+func NewStaged(terminationSignal os.Signal, stages []Members, opts ...Option) StaticGroup {
+	members := Members{}
+	stageOf := map[string]int{}
+
+	var previous []string
+	for i, stage := range stages {
+		if len(stage) == 0 {
+			continue
+		}
+
+		names := make([]string, 0, len(stage))
+		for _, member := range stage {
+			dependsOn := make([]string, 0, len(member.DependsOn)+len(previous))
+			dependsOn = append(dependsOn, member.DependsOn...)
+			member.DependsOn = append(dependsOn, previous...)
+
+			members = append(members, member)
+			stageOf[member.Name] = i + 1
+			names = append(names, member.Name)
+		}
+		previous = names
+	}
+
+	group := newStaticGroup(terminationSignal, members, opts)
+	group.stages = stageOf
+	return &dagGroup{group}
+}
@@ -0,0 +1,134 @@
+package grouper_test
+
+import (
+	"errors"
+	"os"
+	"syscall"
+
+	"github.com/tedsuo/ifrit"
+	"github.com/tedsuo/ifrit/fake_runner"
+	"github.com/tedsuo/ifrit/ginkgomon"
+	"github.com/tedsuo/ifrit/grouper"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Staged Group", func() {
+	var (
+		groupProcess ifrit.Process
+		stages       []grouper.Members
+
+		dbRunner      *fake_runner.TestRunner
+		cacheRunner   *fake_runner.TestRunner
+		apiRunner     *fake_runner.TestRunner
+		ingressRunner *fake_runner.TestRunner
+	)
+
+	BeforeEach(func() {
+		dbRunner = fake_runner.NewTestRunner()
+		cacheRunner = fake_runner.NewTestRunner()
+		apiRunner = fake_runner.NewTestRunner()
+		ingressRunner = fake_runner.NewTestRunner()
+
+		stages = []grouper.Members{
+			{{Name: "db", Runner: dbRunner}, {Name: "cache", Runner: cacheRunner}},
+			{{Name: "api", Runner: apiRunner}},
+			{{Name: "ingress", Runner: ingressRunner}},
+		}
+
+		groupProcess = ifrit.Background(grouper.NewStaged(os.Interrupt, stages))
+	})
+
+	AfterEach(func() {
+		dbRunner.EnsureExit()
+		cacheRunner.EnsureExit()
+		apiRunner.EnsureExit()
+		ingressRunner.EnsureExit()
+
+		ginkgomon.Kill(groupProcess)
+	})
+
+	It("starts each stage in parallel once the previous stage is ready", func() {
+		Eventually(dbRunner.RunCallCount).Should(Equal(1))
+		Eventually(cacheRunner.RunCallCount).Should(Equal(1))
+
+		dbRunner.TriggerReady()
+		Consistently(apiRunner.RunCallCount).Should(Equal(0))
+
+		cacheRunner.TriggerReady()
+		Eventually(apiRunner.RunCallCount).Should(Equal(1))
+		Consistently(ingressRunner.RunCallCount).Should(Equal(0))
+
+		apiRunner.TriggerReady()
+		Eventually(ingressRunner.RunCallCount).Should(Equal(1))
+		Consistently(groupProcess.Ready()).ShouldNot(BeClosed())
+
+		ingressRunner.TriggerReady()
+		Eventually(groupProcess.Ready()).Should(BeClosed())
+	})
+
+	It("does not change the caller's members", func() {
+		Eventually(dbRunner.RunCallCount).Should(Equal(1))
+		Ω(stages[1][0].DependsOn).Should(BeEmpty())
+	})
+
+	Context("once every stage is ready", func() {
+		var dbSignals, cacheSignals, apiSignals, ingressSignals <-chan os.Signal
+
+		BeforeEach(func() {
+			dbSignals = dbRunner.WaitForCall()
+			cacheSignals = cacheRunner.WaitForCall()
+			dbRunner.TriggerReady()
+			cacheRunner.TriggerReady()
+			apiSignals = apiRunner.WaitForCall()
+			apiRunner.TriggerReady()
+			ingressSignals = ingressRunner.WaitForCall()
+			ingressRunner.TriggerReady()
+
+			Eventually(groupProcess.Ready()).Should(BeClosed())
+			groupProcess.Signal(syscall.SIGTERM)
+		})
+
+		It("stops the stages in reverse", func() {
+			Eventually(ingressSignals).Should(Receive(Equal(syscall.SIGTERM)))
+			Consistently(apiSignals).ShouldNot(Receive())
+
+			ingressRunner.TriggerExit(nil)
+			Eventually(apiSignals).Should(Receive(Equal(syscall.SIGTERM)))
+			Consistently(dbSignals).ShouldNot(Receive())
+			Consistently(cacheSignals).ShouldNot(Receive())
+
+			apiRunner.TriggerExit(nil)
+			Eventually(dbSignals).Should(Receive(Equal(syscall.SIGTERM)))
+			Eventually(cacheSignals).Should(Receive(Equal(syscall.SIGTERM)))
+
+			dbRunner.TriggerExit(nil)
+			cacheRunner.TriggerExit(nil)
+			Eventually(groupProcess.Wait()).Should(Receive(BeNil()))
+		})
+
+		It("records the stage of each member in a flat trace", func() {
+			Eventually(ingressSignals).Should(Receive())
+			ingressRunner.TriggerExit(nil)
+			Eventually(apiSignals).Should(Receive())
+			apiRunner.TriggerExit(errors.New("Fail"))
+			Eventually(dbSignals).Should(Receive())
+			dbRunner.TriggerExit(nil)
+			Eventually(cacheSignals).Should(Receive())
+			cacheRunner.TriggerExit(nil)
+
+			var err error
+			Eventually(groupProcess.Wait()).Should(Receive(&err))
+			errTrace := err.(grouper.ErrorTrace)
+			Ω(errTrace).Should(HaveLen(4))
+
+			stageOf := map[string]int{}
+			for _, exit := range errTrace {
+				stageOf[exit.Member.Name] = exit.Stage
+			}
+			Ω(stageOf).Should(Equal(map[string]int{"db": 1, "cache": 1, "api": 2, "ingress": 3}))
+			Ω(errTrace.Error()).Should(ContainSubstring("api (stage 2) exited with error: Fail"))
+		})
+	})
+})
@@ -0,0 +1,238 @@
+package grouper
+
+import (
+	"os"
+	"time"
+
+	"github.com/tedsuo/ifrit"
+)
+
+/*
+A StaticGroup runs a fixed list of members, and provides a StaticClient to
+observe them while the group runs.
+*/
+type StaticGroup interface {
+	ifrit.Runner
+	Client() StaticClient
+}
+
+// staticGroup holds the state shared by every static group strategy.  The
+// pool is only accessed by the goroutine running the group; the client keeps
+// its own record of running members for other goroutines.
+//
+// Every started member is watched by a goroutine which reports its lifecycle
+// on the events channel, so the group waits on a single channel however many
+// members it has.  A member's stopping channel is closed once the group sends
+// it a stop signal, so that its watcher no longer enforces its ReadyTimeout.
+// The pool, exited, stopping and events are allocated afresh by reset at the
+// start of each run, and the client is reset, so that a group can be run
+// again once it exits.
+type staticGroup struct {
+	terminationSignal os.Signal
+	pool              map[string]ifrit.Process
+	exited            map[string]bool
+	stopping          map[string]chan struct{}
+	events            chan memberEvent
+	members           Members
+	client            staticClient
+	options
+
+	// stages maps member names to their stage in a staged group.
+	stages map[string]int
+}
+
+func newStaticGroup(terminationSignal os.Signal, members Members, opts []Option) staticGroup {
+	return staticGroup{
+		terminationSignal: terminationSignal,
+		members:           members,
+		client:            newStaticClient(len(members)),
+		options:           newOptions(opts),
+	}
+}
+
+type memberEventKind int
+
+const (
+	eventReady memberEventKind = iota
+	eventTimedOut
+	eventExited
+)
+
+// A memberEvent reports that the member at index became ready, did not
+// become ready within its ReadyTimeout, or exited.  A member which timed out
+// has already been stopped, and its exit is reported with the event.  Each
+// member reports at most two events, so the buffered events channel never
+// blocks a watcher.
+type memberEvent struct {
+	index int
+	kind  memberEventKind
+	exit  ExitEvent
+}
+
+// A memberStop reports the result of stopping the member at index.
+type memberStop struct {
+	index int
+	err   error
+}
+
+// reset allocates the state of a single run of the group.
+func (g *staticGroup) reset() {
+	g.pool = make(map[string]ifrit.Process)
+	g.exited = make(map[string]bool)
+	g.stopping = make(map[string]chan struct{})
+	g.events = make(chan memberEvent, 2*len(g.members))
+	g.client.reset()
+}
+
+func (g staticGroup) Client() StaticClient {
+	return g.client
+}
+
+// startMember starts the member at index i, adds it to the pool, and watches
+// it for events.
+func (g staticGroup) startMember(signals <-chan os.Signal, i int) ifrit.Process {
+	member := g.members[i]
+	process := member.start(signals)
+	g.pool[member.Name] = process
+	g.client.started(member, process)
+
+	stopping := make(chan struct{})
+	g.stopping[member.Name] = stopping
+
+	go g.watch(i, process, stopping)
+	return process
+}
+
+// stopMember stops a member as options.stopMember does, and first tells its
+// watcher that the member is stopping.
+func (g staticGroup) stopMember(member Member, process ifrit.Process, signal os.Signal, cause error, deadline time.Time) <-chan error {
+	if stopping, ok := g.stopping[member.Name]; ok {
+		close(stopping)
+		delete(g.stopping, member.Name)
+	}
+	return g.options.stopMember(member, process, signal, cause, deadline)
+}
+
+func (g staticGroup) watch(i int, process ifrit.Process, stopping <-chan struct{}) {
+	timer := startReadyTimer(g.members[i])
+	defer timer.Stop()
+
+	select {
+	case <-process.Ready():
+		g.events <- memberEvent{index: i, kind: eventReady}
+	case <-process.Done():
+	case <-stopping:
+	case <-timer.C():
+		exit := stopUnready(g.members[i], process, g.terminationSignal)
+		g.events <- memberEvent{index: i, kind: eventTimedOut, exit: exit}
+		return
+	}
+
+	<-process.Done()
+	g.events <- memberEvent{index: i, kind: eventExited}
+}
+
+// nextEvent waits for an event from a member whose exit has not yet been
+// recorded, or for a signal.
+func (g staticGroup) nextEvent(signals <-chan os.Signal) (memberEvent, os.Signal) {
+	for {
+		select {
+		case event := <-g.events:
+			if g.exited[g.members[event.index].Name] {
+				continue
+			}
+			return event, nil
+		case signal := <-signals:
+			return memberEvent{}, signal
+		}
+	}
+}
+
+// memberReady announces that a member has become ready.
+func (g staticGroup) memberReady(member Member) {
+	g.client.broadcastEntrance(EntranceEvent{Member: member, Process: g.pool[member.Name]})
+}
+
+// memberExited records and announces the exit of a member.
+func (g staticGroup) memberExited(errTrace ErrorTrace, exit ExitEvent) ErrorTrace {
+	exit.Stage = g.stages[exit.Member.Name]
+	g.exited[exit.Member.Name] = true
+	g.client.exited(exit)
+	return append(errTrace, exit)
+}
+
+// recordExit records the exit of the member an eventExited or eventTimedOut
+// refers to.
+func (g staticGroup) recordExit(errTrace ErrorTrace, event memberEvent) (ErrorTrace, ExitEvent) {
+	exit := event.exit
+	if event.kind == eventExited {
+		member := g.members[event.index]
+		process := g.pool[member.Name]
+		exit = newExitEvent(member, process, process.Err())
+	}
+	return g.memberExited(errTrace, exit), exit
+}
+
+// stopInBackground stops the member at index i, and reports the result on
+// stopped.
+func (g staticGroup) stopInBackground(i int, signal os.Signal, cause error, deadline time.Time, stopped chan<- memberStop) {
+	member := g.members[i]
+	exit := g.stopMember(member, g.pool[member.Name], signal, cause, deadline)
+	go func() {
+		stopped <- memberStop{index: i, err: <-exit}
+	}()
+}
+
+// startInTurn starts the member at index i of an ordered group, and waits for
+// it to become ready.  Tolerable exits of the members started before it are
+// recorded along the way.
+func (g staticGroup) startInTurn(signals <-chan os.Signal, i int, errTrace ErrorTrace) (os.Signal, ErrorTrace) {
+	g.startMember(signals, i)
+
+	for {
+		event, signal := g.nextEvent(signals)
+		if signal != nil {
+			return signal, errTrace
+		}
+
+		if event.kind == eventReady {
+			if event.index == i {
+				g.memberReady(g.members[i])
+				return nil, errTrace
+			}
+			continue
+		}
+
+		var exit ExitEvent
+		errTrace, exit = g.recordExit(errTrace, event)
+		if event.index == i || !exit.Member.tolerates(exit.Err) {
+			return nil, errTrace
+		}
+	}
+}
+
+// waitForSignal waits for the group to be signaled, or for a member to exit in
+// a way its Policy does not tolerate.  If every member has exited, the group
+// stops as if it had been sent its termination signal; a group without
+// members runs until it is signaled.
+func (g staticGroup) waitForSignal(signals <-chan os.Signal, errTrace ErrorTrace) (os.Signal, ErrorTrace) {
+	for {
+		if len(g.pool) > 0 && len(g.exited) == len(g.pool) {
+			return g.terminationSignal, errTrace
+		}
+
+		event, signal := g.nextEvent(signals)
+		if signal != nil {
+			return signal, errTrace
+		}
+		if event.kind == eventReady {
+			continue
+		}
+
+		var exit ExitEvent
+		errTrace, exit = g.recordExit(errTrace, event)
+		if !exit.Member.tolerates(exit.Err) {
+			return g.terminationSignal, errTrace
+		}
+	}
+}
@@ -0,0 +1,202 @@
+package grouper_test
+
+import (
+	"errors"
+	"os"
+	"syscall"
+
+	"github.com/tedsuo/ifrit"
+	"github.com/tedsuo/ifrit/fake_runner"
+	"github.com/tedsuo/ifrit/ginkgomon"
+	"github.com/tedsuo/ifrit/grouper"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Static group clients", func() {
+	var (
+		childRunner1 *fake_runner.TestRunner
+		childRunner2 *fake_runner.TestRunner
+		members      grouper.Members
+
+		group        grouper.StaticGroup
+		client       grouper.StaticClient
+		groupProcess ifrit.Process
+	)
+
+	BeforeEach(func() {
+		childRunner1 = fake_runner.NewTestRunner()
+		childRunner2 = fake_runner.NewTestRunner()
+		members = grouper.Members{
+			{Name: "child1", Runner: childRunner1, Labels: grouper.Labels{"tier": "ingress"}},
+			{Name: "child2", Runner: childRunner2, Labels: grouper.Labels{"tier": "backend"}},
+		}
+	})
+
+	AfterEach(func() {
+		childRunner1.EnsureExit()
+		childRunner2.EnsureExit()
+		ginkgomon.Kill(groupProcess)
+	})
+
+	itProvidesAClient := func() {
+		JustBeforeEach(func() {
+			client = group.Client()
+			groupProcess = ifrit.Background(group)
+		})
+
+		It("emits an entrance event when each member becomes ready", func() {
+			entrances := client.EntranceListener()
+
+			childRunner1.TriggerReady()
+			childRunner2.TriggerReady()
+
+			Eventually(entrances).Should(Receive())
+			Eventually(entrances).Should(Receive())
+			Eventually(groupProcess.Ready()).Should(BeClosed())
+
+			Ω(client.EntranceListener()).Should(HaveLen(2))
+		})
+
+		It("gets running members", func() {
+			childRunner1.TriggerReady()
+			childRunner2.TriggerReady()
+			Eventually(groupProcess.Ready()).Should(BeClosed())
+
+			process, ok := client.Get("child1")
+			Ω(ok).Should(BeTrue())
+			Ω(process.Ready()).Should(BeClosed())
+
+			_, ok = client.Get("unknown")
+			Ω(ok).Should(BeFalse())
+		})
+
+		It("emits an exit event when each member exits, and closes its listeners once the group exits", func() {
+			exits := client.ExitListener()
+
+			childRunner1.TriggerReady()
+			childRunner2.TriggerReady()
+			Eventually(groupProcess.Ready()).Should(BeClosed())
+
+			childRunner1.TriggerExit(errors.New("Fail"))
+			Eventually(exits).Should(Receive(matchExitEvent(members[0], errors.New("Fail"))))
+			Eventually(func() bool {
+				_, ok := client.Get("child1")
+				return ok
+			}).Should(BeFalse())
+
+			childRunner2.TriggerExit(nil)
+			Eventually(exits).Should(Receive(matchExitEvent(members[1], nil)))
+			Eventually(exits).Should(BeClosed())
+		})
+
+		Describe("selecting members by label", func() {
+			var signals1, signals2 <-chan os.Signal
+
+			JustBeforeEach(func() {
+				signals1 = childRunner1.WaitForCall()
+				childRunner1.TriggerReady()
+				signals2 = childRunner2.WaitForCall()
+				childRunner2.TriggerReady()
+				Eventually(groupProcess.Ready()).Should(BeClosed())
+			})
+
+			It("lists the running members matching a selector", func() {
+				Ω(client.Selected(grouper.Selector{"tier": "ingress"})).Should(Equal([]grouper.Member{members[0]}))
+				Ω(client.Selected(grouper.Selector{})).Should(Equal([]grouper.Member{members[0], members[1]}))
+				Ω(client.Selected(grouper.Selector{"tier": "unknown"})).Should(BeEmpty())
+			})
+
+			It("signals only the matching members", func() {
+				Ω(client.SignalSelected(grouper.Selector{"tier": "ingress"}, syscall.SIGUSR2)).Should(Equal([]string{"child1"}))
+				Eventually(signals1).Should(Receive(Equal(syscall.SIGUSR2)))
+				Consistently(signals2).ShouldNot(Receive())
+			})
+
+			It("filters events by selector", func() {
+				entrances := client.SelectedEntranceListener(grouper.Selector{"tier": "backend"})
+				exits := client.SelectedExitListener(grouper.Selector{"tier": "backend"})
+
+				Eventually(entrances).Should(Receive(WithTransform(func(e grouper.EntranceEvent) string {
+					return e.Member.Name
+				}, Equal("child2"))))
+				Consistently(entrances).ShouldNot(Receive())
+
+				childRunner1.TriggerExit(nil)
+				Eventually(signals2).Should(Receive())
+				childRunner2.TriggerExit(nil)
+				Eventually(exits).Should(Receive(matchExitEvent(members[1], nil)))
+				Eventually(exits).Should(BeClosed())
+			})
+		})
+	}
+
+	Context("for an ordered group", func() {
+		BeforeEach(func() {
+			group = grouper.NewOrdered(os.Interrupt, members)
+		})
+
+		itProvidesAClient()
+	})
+
+	Context("for a parallel group", func() {
+		BeforeEach(func() {
+			group = grouper.NewParallel(os.Interrupt, members)
+		})
+
+		itProvidesAClient()
+	})
+})
+
+var _ = Describe("Running a static group again", func() {
+	untilSignaled := ifrit.RunFunc(func(signals <-chan os.Signal, ready chan<- struct{}) error {
+		close(ready)
+		<-signals
+		return nil
+	})
+
+	members := grouper.Members{
+		{Name: "child1", Runner: untilSignaled},
+		{Name: "child2", Runner: untilSignaled},
+	}
+
+	itRunsAgain := func(newGroup func() grouper.StaticGroup) {
+		It("runs its members again once it has exited, with a working client", func() {
+			group := newGroup()
+			client := group.Client()
+
+			for run := 0; run < 2; run++ {
+				groupProcess := ifrit.Background(group)
+				Eventually(groupProcess.Ready()).Should(BeClosed())
+
+				entrances := client.EntranceListener()
+				exits := client.ExitListener()
+				Ω(entrances).Should(HaveLen(2))
+				Consistently(exits).ShouldNot(Receive())
+				_, ok := client.Get("child1")
+				Ω(ok).Should(BeTrue())
+
+				groupProcess.Signal(os.Interrupt)
+				Eventually(groupProcess.Wait()).Should(Receive(BeNil()))
+				var exit1, exit2 grouper.ExitEvent
+				Eventually(exits).Should(Receive(&exit1))
+				Eventually(exits).Should(Receive(&exit2))
+				Ω([]string{exit1.Member.Name, exit2.Member.Name}).Should(ConsistOf("child1", "child2"))
+				Eventually(exits).Should(BeClosed())
+			}
+		})
+	}
+
+	Context("for an ordered group", func() {
+		itRunsAgain(func() grouper.StaticGroup {
+			return grouper.NewOrdered(os.Interrupt, members)
+		})
+	})
+
+	Context("for a parallel group", func() {
+		itRunsAgain(func() grouper.StaticGroup {
+			return grouper.NewParallel(os.Interrupt, members)
+		})
+	})
+})
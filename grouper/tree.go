@@ -0,0 +1,134 @@
+package grouper
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tedsuo/ifrit"
+)
+
+// memberLookup is implemented by the clients of every group, so that paths
+// and trees can be followed through nested groups.
+type memberLookup interface {
+	lookup(name string) (runningMember, bool)
+	runningMembers() []runningMember
+}
+
+// nestedClient returns the client of a member which is itself a group.
+func nestedClient(member Member) (memberLookup, bool) {
+	var client interface{}
+	switch group := member.Runner.(type) {
+	case StaticGroup:
+		client = group.Client()
+	case DynamicGroup:
+		client = group.Client()
+	default:
+		return nil, false
+	}
+
+	lookup, ok := client.(memberLookup)
+	return lookup, ok
+}
+
+// getPath follows a path of member names, such as "services/api/http",
+// through nested groups.
+func getPath(client memberLookup, path string) (ifrit.Process, bool) {
+	name, rest, nested := strings.Cut(path, "/")
+	member, ok := client.lookup(name)
+	if !ok {
+		return nil, false
+	}
+	if !nested {
+		return member.process, true
+	}
+
+	inner, ok := nestedClient(member.Member)
+	if !ok {
+		return nil, false
+	}
+	return getPath(inner, rest)
+}
+
+/*
+A Node describes a running member of a group.  If the member is itself a
+group, its running members are its Children.
+*/
+type Node struct {
+	Name     string
+	Path     string
+	Labels   Labels
+	Process  ifrit.Process
+	State    ifrit.State
+	Children Tree
+}
+
+/*
+A Tree describes the running members of a group, and of every group nested
+within it.
+*/
+type Tree []Node
+
+func newTree(client memberLookup) Tree {
+	return buildTree(client, "")
+}
+
+func buildTree(client memberLookup, prefix string) Tree {
+	members := client.runningMembers()
+	if members == nil {
+		return nil
+	}
+
+	tree := make(Tree, 0, len(members))
+	for _, member := range members {
+		node := Node{
+			Name:    member.Name,
+			Path:    prefix + member.Name,
+			Labels:  member.Labels,
+			Process: member.process,
+			State:   member.process.State(),
+		}
+		if inner, ok := nestedClient(member.Member); ok {
+			node.Children = buildTree(inner, node.Path+"/")
+		}
+		tree = append(tree, node)
+	}
+	return tree
+}
+
+/*
+Find returns the node at a path, such as "services/api/http".
+*/
+func (t Tree) Find(path string) (Node, bool) {
+	name, rest, nested := strings.Cut(path, "/")
+	for _, node := range t {
+		if node.Name != name {
+			continue
+		}
+		if !nested {
+			return node, true
+		}
+		return node.Children.Find(rest)
+	}
+	return Node{}, false
+}
+
+/*
+String renders the tree with one member per line, indented beneath the group
+which runs it:
+
+	services [ready]
+	  api [ready]
+	    http [starting]
+*/
+func (t Tree) String() string {
+	var b strings.Builder
+	t.write(&b, 0)
+	return b.String()
+}
+
+func (t Tree) write(b *strings.Builder, depth int) {
+	for _, node := range t {
+		fmt.Fprintf(b, "%s%s [%s]\n", strings.Repeat("  ", depth), node.Name, node.State)
+		node.Children.write(b, depth+1)
+	}
+}
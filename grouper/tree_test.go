@@ -0,0 +1,192 @@
+package grouper_test
+
+import (
+	"errors"
+	"os"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/tedsuo/ifrit"
+	"github.com/tedsuo/ifrit/fake_runner"
+	"github.com/tedsuo/ifrit/ginkgomon"
+	"github.com/tedsuo/ifrit/grouper"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Nested groups", func() {
+	var (
+		dbRunner     *fake_runner.TestRunner
+		httpRunner   *fake_runner.TestRunner
+		workerRunner *fake_runner.TestRunner
+
+		httpSignals <-chan os.Signal
+	)
+
+	BeforeEach(func() {
+		dbRunner = fake_runner.NewTestRunner()
+		httpRunner = fake_runner.NewTestRunner()
+		workerRunner = fake_runner.NewTestRunner()
+	})
+
+	services := func() grouper.StaticGroup {
+		return grouper.NewParallel(os.Interrupt, grouper.Members{
+			{Name: "api", Runner: grouper.NewParallel(os.Interrupt, grouper.Members{
+				{Name: "http", Runner: httpRunner},
+			})},
+			{Name: "worker", Runner: workerRunner},
+		})
+	}
+
+	Describe("a static group", func() {
+		var (
+			client       grouper.StaticClient
+			groupProcess ifrit.Process
+		)
+
+		BeforeEach(func() {
+			group := grouper.NewOrdered(os.Interrupt, grouper.Members{
+				{Name: "db", Runner: dbRunner},
+				{Name: "services", Runner: services()},
+			})
+			client = group.Client()
+			groupProcess = ifrit.Background(group)
+
+			dbRunner.WaitForCall()
+			dbRunner.TriggerReady()
+			httpSignals = httpRunner.WaitForCall()
+			workerRunner.WaitForCall()
+			workerRunner.TriggerReady()
+		})
+
+		AfterEach(func() {
+			dbRunner.EnsureExit()
+			httpRunner.EnsureExit()
+			workerRunner.EnsureExit()
+
+			ginkgomon.Kill(groupProcess)
+		})
+
+		It("gets a member of a nested group by path", func() {
+			process, ok := client.Get("services/api/http")
+			Ω(ok).Should(BeTrue())
+
+			process.Signal(syscall.SIGUSR2)
+			Eventually(httpSignals).Should(Receive(Equal(syscall.SIGUSR2)))
+		})
+
+		It("does not find paths which do not lead to a running member", func() {
+			_, ok := client.Get("services/api/grpc")
+			Ω(ok).Should(BeFalse())
+
+			_, ok = client.Get("db/primary")
+			Ω(ok).Should(BeFalse())
+
+			_, ok = client.Get("unknown/api")
+			Ω(ok).Should(BeFalse())
+		})
+
+		It("dumps the tree of running members with their states", func() {
+			tree := client.Tree()
+
+			node, ok := tree.Find("services/api/http")
+			Ω(ok).Should(BeTrue())
+			Ω(node.Path).Should(Equal("services/api/http"))
+			Ω(node.State).Should(Equal(ifrit.Starting))
+
+			httpRunner.TriggerReady()
+			Eventually(groupProcess.Ready()).Should(BeClosed())
+
+			Ω(client.Tree().String()).Should(Equal(
+				"db [ready]\n" +
+					"services [ready]\n" +
+					"  api [ready]\n" +
+					"    http [ready]\n" +
+					"  worker [ready]\n",
+			))
+		})
+	})
+
+	Describe("a group whose members change", func() {
+		var (
+			client       grouper.StaticClient
+			groupProcess ifrit.Process
+		)
+
+		BeforeEach(func() {
+			failing := grouper.Member{Name: "failing", Runner: ifrit.RunFunc(func(signals <-chan os.Signal, ready chan<- struct{}) error {
+				close(ready)
+				return errors.New("Fail")
+			})}
+			group := grouper.NewHotStandby(os.Interrupt, failing, func() grouper.Member { return failing }, grouper.StartStandbyOnFailover, grouper.WithFailoverBackoff(0, 0))
+			client = group.Client()
+			groupProcess = ifrit.Background(group)
+		})
+
+		AfterEach(func() {
+			ginkgomon.Kill(groupProcess)
+		})
+
+		It("looks up paths while members start and exit", func() {
+			done := make(chan struct{})
+			var wg sync.WaitGroup
+			for i := 0; i < 8; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for start := time.Now(); time.Since(start) < 500*time.Millisecond; {
+						client.Get("nope/child")
+					}
+				}()
+			}
+			go func() {
+				wg.Wait()
+				close(done)
+			}()
+
+			Eventually(done, 5*time.Second).Should(BeClosed())
+		})
+	})
+
+	Describe("a dynamic group", func() {
+		var (
+			client      grouper.DynamicClient
+			poolProcess ifrit.Process
+		)
+
+		BeforeEach(func() {
+			pool := grouper.NewDynamic(nil, 2, 2)
+			client = pool.Client()
+			poolProcess = ifrit.Invoke(pool)
+
+			Ω(client.TryInsert(grouper.Member{Name: "services", Runner: services()})).Should(Succeed())
+			httpSignals = httpRunner.WaitForCall()
+			workerRunner.WaitForCall()
+		})
+
+		AfterEach(func() {
+			poolProcess.Signal(os.Kill)
+			Eventually(func() <-chan struct{} {
+				httpRunner.EnsureExit()
+				workerRunner.EnsureExit()
+				return poolProcess.Done()
+			}).Should(BeClosed())
+		})
+
+		It("gets a member of a nested group by path", func() {
+			process, ok := client.Get("services/api/http")
+			Ω(ok).Should(BeTrue())
+
+			process.Signal(syscall.SIGUSR2)
+			Eventually(httpSignals).Should(Receive(Equal(syscall.SIGUSR2)))
+		})
+
+		It("dumps the tree of members", func() {
+			node, ok := client.Tree().Find("services/worker")
+			Ω(ok).Should(BeTrue())
+			Ω(node.State).Should(Equal(ifrit.Starting))
+		})
+	})
+})
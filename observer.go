@@ -0,0 +1,37 @@
+package ifrit
+
+import (
+	"os"
+	"time"
+)
+
+/*
+An Observer is notified as a Process moves through its lifecycle.  Observers
+are called synchronously from the Process's goroutines, and should return
+quickly.
+
+Observers are inherited by child processes (see ChildOf), so an Observer
+attached to the root of a process tree sees every process in the tree.
+*/
+type Observer interface {
+	// OnStart is called before the Process's Runner is run.
+	OnStart(p Process)
+
+	// OnReady is called once the Process becomes ready.
+	OnReady(p Process)
+
+	// OnSignal is called every time the Process is signaled before exiting.
+	OnSignal(p Process, signal os.Signal)
+
+	// OnExit is called once the Process has exited, with its run time and exit error.
+	OnExit(p Process, duration time.Duration, err error)
+}
+
+/*
+WithObserver attaches an Observer to a Process.
+*/
+func WithObserver(observer Observer) Option {
+	return func(o *options) {
+		o.observers = append(o.observers, observer)
+	}
+}
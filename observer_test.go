@@ -0,0 +1,48 @@
+package ifrit_test
+
+import (
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/tedsuo/ifrit"
+	"github.com/tedsuo/ifrit/test_helpers"
+)
+
+var _ = Describe("Observer", func() {
+	var observer *test_helpers.ObserverRecorder
+
+	BeforeEach(func() {
+		observer = new(test_helpers.ObserverRecorder)
+	})
+
+	It("is notified as the process moves through its lifecycle", func() {
+		proc := ifrit.Invoke(make(test_helpers.PingChan), ifrit.WithObserver(observer), ifrit.WithName("pinger"))
+		proc.Signal(os.Interrupt)
+		Eventually(proc.Wait()).Should(Receive())
+
+		Eventually(observer.Events).Should(Equal([]string{
+			"start pinger",
+			"ready pinger",
+			"signal pinger",
+			"exit pinger",
+		}))
+	})
+
+	It("is inherited by child processes", func() {
+		parent := ifrit.RunFunc(func(signals <-chan os.Signal, ready chan<- struct{}) error {
+			child := ifrit.Invoke(test_helpers.NoReadyRunner, ifrit.ChildOf(signals), ifrit.WithName("child"))
+			return <-child.Wait()
+		})
+
+		proc := ifrit.Background(parent, ifrit.WithObserver(observer), ifrit.WithName("parent"))
+		Eventually(proc.Wait()).Should(Receive(Equal(test_helpers.NoReadyExitedNormally)))
+
+		Eventually(observer.Events).Should(Equal([]string{
+			"start parent",
+			"start parent/child",
+			"exit parent/child",
+			"exit parent",
+		}))
+	})
+})
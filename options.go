@@ -0,0 +1,76 @@
+package ifrit
+
+import (
+	"os"
+	"sync"
+)
+
+/*
+An Option configures a Process started by Background or Invoke.
+*/
+type Option func(*options)
+
+type options struct {
+	name      string
+	parent    *process
+	observers []Observer
+
+	signalQueueSize int
+	signalOverflow  SignalOverflow
+}
+
+func newOptions(opts []Option) options {
+	o := options{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if o.parent != nil {
+		switch {
+		case o.parent.name == "":
+		case o.name == "":
+			o.name = o.parent.name
+		default:
+			o.name = o.parent.name + "/" + o.name
+		}
+		o.observers = append(append([]Observer(nil), o.parent.observers...), o.observers...)
+	}
+
+	return o
+}
+
+/*
+WithName names a Process.  The name is reported in the Process's Status, and
+is prefixed with the name of its parent, if any, to form a path such as
+"api/http".
+*/
+func WithName(name string) Option {
+	return func(o *options) {
+		o.name = name
+	}
+}
+
+/*
+ChildOf marks a Process as a child of the running Process which owns the given
+signals channel.  The child inherits its parent's Observers and name.  Runners
+which start other Runners, such as groups, should start them as children of
+themselves:
+
+	func (r myRunner) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+		child := ifrit.Background(r.child, ifrit.ChildOf(signals))
+		...
+	}
+
+ChildOf has no effect if the signals channel does not belong to a running
+Process.
+*/
+func ChildOf(signals <-chan os.Signal) Option {
+	return func(o *options) {
+		if parent, ok := runningProcesses.Load(signals); ok {
+			o.parent = parent.(*process)
+		}
+	}
+}
+
+// runningProcesses maps the signals channel of every running process to the process.
+var runningProcesses = new(sync.Map)
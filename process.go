@@ -1,6 +1,13 @@
 package ifrit
 
-import "os"
+import (
+	"context"
+	"fmt"
+	"os"
+	"runtime/debug"
+	"sync"
+	"time"
+)
 
 /*
 A Process represents a Runner that has been started.  It is safe to call any
@@ -13,8 +20,26 @@ type Process interface {
 	// Wait returns a channel that will emit a single error once the Process exits.
 	Wait() <-chan error
 
+	// Done returns a channel which will close once the Process exits.  Every
+	// call returns the same channel.
+	Done() <-chan struct{}
+
+	// Err returns the error the Process exited with, or nil if it is still running.
+	Err() error
+
 	// Signal sends a shutdown signal to the Process.  It does not block.
+	// Signals are delivered to the Runner in the order they were sent.
 	Signal(os.Signal)
+
+	// Context returns a context which is cancelled once the Process exits.
+	// context.Cause reports the exit error, or context.Canceled for a nil exit.
+	Context() context.Context
+
+	// State returns the current phase of the Process's lifecycle.
+	State() State
+
+	// Status returns a snapshot of the Process's lifecycle.
+	Status() Status
 }
 
 /*
@@ -23,17 +48,54 @@ for ready allows program initializtion to be scripted in a procedural manner.
 To orcestrate the startup and monitoring of multiple Processes, please refer to
 the ifrit/grouper package.
 */
-func Invoke(r Runner) Process {
-	p := Background(r)
+func Invoke(r Runner, opts ...Option) Process {
+	p := Background(r, opts...)
 
 	select {
 	case <-p.Ready():
-	case <-p.Wait():
+	case <-p.Done():
 	}
 
 	return p
 }
 
+/*
+InvokeWithTimeout executes a Runner and returns a Process once the Runner is
+ready or has exited. If the Runner does not become ready within the timeout,
+the Process is sent os.Interrupt and returned along with an ErrReadyTimeout,
+so that the caller may wait for it to exit.  A Process which ignores the
+interrupt is sent os.Kill once a further timeout has elapsed.
+*/
+func InvokeWithTimeout(r Runner, timeout time.Duration, opts ...Option) (Process, error) {
+	p := Background(r, opts...)
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case <-p.Ready():
+	case <-p.Done():
+	case <-timer.C:
+		p.Signal(os.Interrupt)
+		go Escalation{{Grace: timeout}, {Signal: os.Kill, Grace: timeout}}.Stop(p)
+		return p, ErrReadyTimeout{Timeout: timeout}
+	}
+
+	return p, nil
+}
+
+/*
+ErrReadyTimeout is returned by InvokeWithTimeout when a Runner does not become
+ready in time.
+*/
+type ErrReadyTimeout struct {
+	Timeout time.Duration
+}
+
+func (e ErrReadyTimeout) Error() string {
+	return fmt.Sprintf("process was not ready after %s", e.Timeout)
+}
+
 /*
 Envoke is deprecated in favor of Invoke, on account of it not being a real word.
 */
@@ -43,55 +105,250 @@ func Envoke(r Runner) Process {
 
 /*
 Background executes a Runner and returns a Process immediately, without waiting.
+
+If the Runner panics, the panic is recovered and the Process exits with a
+PanicError.
 */
-func Background(r Runner) Process {
-	p := newProcess(r)
+func Background(r Runner, opts ...Option) Process {
+	p := newProcess(r, newOptions(opts))
+	runningProcesses.Store((<-chan os.Signal)(p.signals), p)
 	go p.run()
 	return p
 }
 
 type process struct {
 	runner     Runner
+	name       string
+	observers  []Observer
 	signals    chan os.Signal
 	ready      chan struct{}
 	exited     chan struct{}
 	exitStatus error
+	waiters    []chan error // nil once the process has exited
+	ctx        context.Context
+	cancel     context.CancelCauseFunc
+
+	signalQueue   *signalQueue
+	deliverySetup *sync.Once
+
+	statusLock *sync.Mutex
+	status     Status
 }
 
-func newProcess(runner Runner) *process {
+func newProcess(runner Runner, opts options) *process {
+	ctx, cancel := context.WithCancelCause(context.Background())
 	return &process{
-		runner:  runner,
-		signals: make(chan os.Signal),
-		ready:   make(chan struct{}),
-		exited:  make(chan struct{}),
+		runner:        runner,
+		name:          opts.name,
+		observers:     opts.observers,
+		signals:       make(chan os.Signal),
+		ready:         make(chan struct{}),
+		exited:        make(chan struct{}),
+		waiters:       []chan error{},
+		ctx:           ctx,
+		cancel:        cancel,
+		signalQueue:   newSignalQueue(opts.signalQueueSize, opts.signalOverflow),
+		deliverySetup: new(sync.Once),
+		statusLock:    new(sync.Mutex),
+		status: Status{
+			Name:      opts.name,
+			State:     Starting,
+			StartedAt: time.Now(),
+		},
 	}
 }
 
 func (p *process) run() {
-	p.exitStatus = p.runner.Run(p.signals, p.ready)
+	for _, observer := range p.observers {
+		observer.OnStart(p)
+	}
+
+	go p.waitForReady()
+	p.exitStatus = p.runRunner()
+	runningProcesses.Delete((<-chan os.Signal)(p.signals))
+	p.markExited(p.exitStatus)
+	p.cancel(p.exitStatus)
 	close(p.exited)
+	p.notifyWaiters()
+}
+
+func (p *process) runRunner() (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = PanicError{Value: r, Stack: debug.Stack()}
+		}
+	}()
+
+	return p.runner.Run(p.signals, p.ready)
+}
+
+func (p *process) waitForReady() {
+	select {
+	case <-p.ready:
+		p.markReady()
+	case <-p.exited:
+	}
+}
+
+// checkReady marks the process ready if its ready channel has been closed,
+// so that the status never lags behind what a caller has observed.
+func (p *process) checkReady() {
+	select {
+	case <-p.ready:
+		p.markReady()
+	default:
+	}
+}
+
+func (p *process) markReady() {
+	p.statusLock.Lock()
+	if !p.status.ReadyAt.IsZero() {
+		p.statusLock.Unlock()
+		return
+	}
+	p.status.ReadyAt = time.Now()
+	if p.status.State == Starting {
+		p.status.State = Ready
+	}
+	p.statusLock.Unlock()
+
+	for _, observer := range p.observers {
+		observer.OnReady(p)
+	}
+}
+
+func (p *process) markExited(err error) {
+	p.checkReady()
+
+	p.statusLock.Lock()
+	p.status.State = Exited
+	p.status.ExitedAt = time.Now()
+	p.status.Err = err
+	duration := p.status.ExitedAt.Sub(p.status.StartedAt)
+	p.statusLock.Unlock()
+
+	for _, observer := range p.observers {
+		observer.OnExit(p, duration, err)
+	}
+}
+
+func (p *process) markSignaled(signal os.Signal, cause error) {
+	p.checkReady()
+
+	p.statusLock.Lock()
+	if p.status.State == Exited {
+		p.statusLock.Unlock()
+		return
+	}
+	if p.status.Cause == nil {
+		if cause == nil {
+			cause = ErrSignaled{Signal: signal}
+		}
+		p.status.Cause = cause
+	}
+	p.status.State = Stopping
+	p.status.Signals = append(p.status.Signals, signal)
+	p.statusLock.Unlock()
+
+	for _, observer := range p.observers {
+		observer.OnSignal(p, signal)
+	}
+}
+
+func (p *process) State() State {
+	p.checkReady()
+
+	p.statusLock.Lock()
+	defer p.statusLock.Unlock()
+
+	return p.status.State
+}
+
+func (p *process) Status() Status {
+	p.checkReady()
+
+	p.statusLock.Lock()
+	defer p.statusLock.Unlock()
+
+	status := p.status
+	status.Signals = append([]os.Signal(nil), p.status.Signals...)
+	return status
 }
 
 func (p *process) Ready() <-chan struct{} {
 	return p.ready
 }
 
+func (p *process) Context() context.Context {
+	return p.ctx
+}
+
+func (p *process) Done() <-chan struct{} {
+	return p.exited
+}
+
+func (p *process) Err() error {
+	select {
+	case <-p.exited:
+		return p.exitStatus
+	default:
+		return nil
+	}
+}
+
 func (p *process) Wait() <-chan error {
 	exitChan := make(chan error, 1)
 
-	go func() {
-		<-p.exited
+	p.statusLock.Lock()
+	defer p.statusLock.Unlock()
+
+	if p.waiters == nil {
 		exitChan <- p.exitStatus
-	}()
+	} else {
+		p.waiters = append(p.waiters, exitChan)
+	}
 
 	return exitChan
 }
 
+func (p *process) notifyWaiters() {
+	p.statusLock.Lock()
+	defer p.statusLock.Unlock()
+
+	for _, exitChan := range p.waiters {
+		exitChan <- p.exitStatus
+	}
+	p.waiters = nil
+}
+
 func (p *process) Signal(signal os.Signal) {
-	go func() {
-		select {
-		case p.signals <- signal:
-		case <-p.exited:
-		}
-	}()
+	p.signalWithCause(signal, nil)
+}
+
+func (p *process) signalWithCause(signal os.Signal, cause error) {
+	p.markSignaled(signal, cause)
+
+	p.deliverySetup.Do(func() {
+		go p.signalQueue.deliver(p.signals, p.exited)
+	})
+	p.signalQueue.push(signal)
+}
+
+/*
+PanicError is the exit error of a Process whose Runner panicked. It carries the
+recovered value, and the stack trace of the panicking goroutine.
+*/
+type PanicError struct {
+	Value interface{}
+	Stack []byte
+}
+
+func (e PanicError) Error() string {
+	return fmt.Sprintf("runner panicked: %v", e.Value)
+}
+
+// Unwrap returns the recovered value if it is an error.
+func (e PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
 }
@@ -1,11 +1,15 @@
 package ifrit_test
 
 import (
+	"errors"
 	"os"
+	"syscall"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/tedsuo/ifrit"
+	"github.com/tedsuo/ifrit/fake_runner"
 	"github.com/tedsuo/ifrit/test_helpers"
 )
 
@@ -47,6 +51,24 @@ var _ = Describe("Process", func() {
 			})
 		})
 
+		Describe("Done() and Err()", func() {
+			It("returns the same channel on every call", func() {
+				Ω(pingProc.Done()).Should(Equal(pingProc.Done()))
+			})
+
+			It("has no error while the process is running", func() {
+				Consistently(pingProc.Done()).ShouldNot(BeClosed())
+				Ω(pingProc.Err()).Should(BeNil())
+			})
+
+			It("closes Done and reports the run result once the process exits", func() {
+				<-pinger
+				Eventually(pingProc.Done()).Should(BeClosed())
+				Ω(pingProc.Err()).Should(Equal(test_helpers.PingerExitedFromPing))
+				Ω(<-pingProc.Wait()).Should(Equal(test_helpers.PingerExitedFromPing))
+			})
+		})
+
 		Describe("Signal()", func() {
 			BeforeEach(func() {
 				pingProc.Signal(os.Kill)
@@ -71,4 +93,104 @@ var _ = Describe("Process", func() {
 			Ω(<-proc.Wait()).Should(Equal(test_helpers.NoReadyExitedNormally))
 		})
 	})
+
+	Context("when a runner panics", func() {
+		It("exits with a PanicError carrying the panic value and stack", func() {
+			proc := ifrit.Background(ifrit.RunFunc(func(signals <-chan os.Signal, ready chan<- struct{}) error {
+				panic("boom")
+			}))
+
+			var err error
+			Eventually(proc.Wait()).Should(Receive(&err))
+
+			var panicErr ifrit.PanicError
+			Ω(errors.As(err, &panicErr)).Should(BeTrue())
+			Ω(panicErr.Value).Should(Equal("boom"))
+			Ω(string(panicErr.Stack)).Should(ContainSubstring("process_test.go"))
+		})
+	})
+
+	Describe("InvokeWithTimeout", func() {
+		It("returns the process once it is ready", func() {
+			pinger := make(test_helpers.PingChan)
+			proc, err := ifrit.InvokeWithTimeout(pinger, time.Second)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(proc.Ready()).Should(BeClosed())
+			proc.Signal(os.Kill)
+			Eventually(proc.Wait()).Should(Receive(Equal(test_helpers.PingerExitedFromSignal)))
+		})
+
+		It("signals the process and returns ErrReadyTimeout when the process is not ready in time", func() {
+			runner := fake_runner.NewTestRunner()
+			defer runner.EnsureExit()
+
+			proc, err := ifrit.InvokeWithTimeout(runner, 10*time.Millisecond)
+			Ω(err).Should(Equal(ifrit.ErrReadyTimeout{Timeout: 10 * time.Millisecond}))
+			Ω(proc.Status().Signals).Should(Equal([]os.Signal{os.Interrupt}))
+			Eventually(runner.WaitForCall()).Should(Receive(Equal(os.Interrupt)))
+		})
+
+		It("kills the process if it ignores the interrupt", func() {
+			runner := fake_runner.NewTestRunner()
+			defer runner.EnsureExit()
+
+			_, err := ifrit.InvokeWithTimeout(runner, 10*time.Millisecond)
+			Ω(err).Should(Equal(ifrit.ErrReadyTimeout{Timeout: 10 * time.Millisecond}))
+
+			signals := runner.WaitForCall()
+			Eventually(signals).Should(Receive(Equal(os.Interrupt)))
+			Eventually(signals).Should(Receive(Equal(os.Kill)))
+		})
+	})
+
+	Describe("Status()", func() {
+		var runner *fake_runner.TestRunner
+		var proc ifrit.Process
+
+		BeforeEach(func() {
+			runner = fake_runner.NewTestRunner()
+			proc = ifrit.Background(runner)
+			runner.WaitForCall()
+		})
+
+		AfterEach(func() {
+			runner.EnsureExit()
+		})
+
+		It("starts out starting", func() {
+			status := proc.Status()
+			Ω(status.State).Should(Equal(ifrit.Starting))
+			Ω(status.StartedAt).ShouldNot(BeZero())
+			Ω(status.ReadyAt).Should(BeZero())
+			Ω(status.ExitedAt).Should(BeZero())
+		})
+
+		It("becomes ready once the runner is ready", func() {
+			runner.TriggerReady()
+			Eventually(proc.State).Should(Equal(ifrit.Ready))
+			Ω(proc.Status().ReadyAt).ShouldNot(BeZero())
+		})
+
+		It("is stopping once signaled, and records the signals", func() {
+			runner.TriggerReady()
+			Eventually(proc.State).Should(Equal(ifrit.Ready))
+
+			proc.Signal(syscall.SIGUSR2)
+			proc.Signal(os.Interrupt)
+			Ω(proc.State()).Should(Equal(ifrit.Stopping))
+			Ω(proc.Status().Signals).Should(Equal([]os.Signal{syscall.SIGUSR2, os.Interrupt}))
+		})
+
+		It("reports the exit error once exited", func() {
+			runner.TriggerReady()
+			runner.TriggerExit(test_helpers.PingerExitedFromPing)
+			Eventually(proc.Wait()).Should(Receive())
+
+			status := proc.Status()
+			Ω(status.State).Should(Equal(ifrit.Exited))
+			Ω(status.Err).Should(Equal(test_helpers.PingerExitedFromPing))
+			Ω(status.ReadyAt).ShouldNot(BeZero())
+			Ω(status.ExitedAt).ShouldNot(BeZero())
+		})
+	})
 })
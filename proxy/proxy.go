@@ -8,21 +8,22 @@ import (
 
 func New(proxySignals <-chan os.Signal, runner ifrit.Runner) ifrit.Runner {
 	return ifrit.RunFunc(func(signals <-chan os.Signal, ready chan<- struct{}) error {
-		process := ifrit.Background(runner)
+		process := ifrit.Background(runner, ifrit.ChildOf(signals))
 		<-process.Ready()
 		close(ready)
 		go forwardSignals(proxySignals, process)
 		go forwardSignals(signals, process)
-		return <-process.Wait()
+		<-process.Done()
+		return process.Err()
 	})
 }
 
 func forwardSignals(signals <-chan os.Signal, process ifrit.Process) {
-	exit := process.Wait()
+	exit := process.Done()
 	for {
 		select {
 		case sig := <-signals:
-			process.Signal(sig)
+			ifrit.SignalWithCause(process, sig, ifrit.Cause(signals))
 		case <-exit:
 			return
 		}
@@ -32,22 +32,23 @@ func (r Restarter) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
 		return ErrNoLoadCallback
 	}
 
-	process := ifrit.Background(r.Runner)
+	process := ifrit.Background(r.Runner, ifrit.ChildOf(signals))
 	processReady := process.Ready()
-	exit := process.Wait()
+	exit := process.Done()
 	signaled := false
 
 	for {
 		select {
 		case signal := <-signals:
-			process.Signal(signal)
+			ifrit.SignalWithCause(process, signal, ifrit.Cause(signals))
 			signaled = true
 
 		case <-processReady:
 			close(ready)
 			processReady = nil
 
-		case err := <-exit:
+		case <-exit:
+			err := process.Err()
 			if signaled {
 				return err
 			}
@@ -56,8 +57,8 @@ func (r Restarter) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
 			if r.Runner == nil {
 				return err
 			}
-			process = ifrit.Background(r.Runner)
-			exit = process.Wait()
+			process = ifrit.Background(r.Runner, ifrit.ChildOf(signals))
+			exit = process.Done()
 		}
 	}
 }
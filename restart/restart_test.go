@@ -87,6 +87,26 @@ var _ = Describe("Restart", func() {
 			})
 		})
 
+		Context("when the runner panics", func() {
+			var loadErrs chan error
+
+			BeforeEach(func() {
+				loadErrs = make(chan error, 1)
+				restarter.Runner = ifrit.RunFunc(func(signals <-chan os.Signal, ready chan<- struct{}) error {
+					panic("boom")
+				})
+				restarter.Load = func(runner ifrit.Runner, err error) ifrit.Runner {
+					loadErrs <- err
+					return nil
+				}
+			})
+
+			It("passes a PanicError to load, like any other exit", func() {
+				Eventually(loadErrs).Should(Receive(BeAssignableToTypeOf(ifrit.PanicError{})))
+				Eventually(process.Wait()).Should(Receive(BeAssignableToTypeOf(ifrit.PanicError{})))
+			})
+		})
+
 		Context("when the load callback is nil", func() {
 			BeforeEach(func() {
 				restarter.Load = nil
@@ -27,22 +27,22 @@ func (s sigmon) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
 	osSignals := make(chan os.Signal, SIGNAL_BUFFER_SIZE)
 	signal.Notify(osSignals, s.Signals...)
 
-	process := ifrit.Background(s.Runner)
+	process := ifrit.Background(s.Runner, ifrit.ChildOf(signals))
 	pReady := process.Ready()
-	pWait := process.Wait()
+	pDone := process.Done()
 
 	for {
 		select {
 		case sig := <-signals:
-			process.Signal(sig)
+			ifrit.SignalWithCause(process, sig, ifrit.Cause(signals))
 		case sig := <-osSignals:
 			process.Signal(sig)
 		case <-pReady:
 			close(ready)
 			pReady = nil
-		case err := <-pWait:
+		case <-pDone:
 			signal.Stop(osSignals)
-			return err
+			return process.Err()
 		}
 	}
 }
@@ -0,0 +1,117 @@
+package ifrit
+
+import (
+	"os"
+	"sync"
+)
+
+/*
+SignalOverflow decides what happens when a signal is sent to a Process whose
+signal queue is full.
+*/
+type SignalOverflow int
+
+const (
+	// CoalesceSignals drops the new signal if it is already queued.  Otherwise,
+	// the oldest queued signal is dropped to make room.
+	CoalesceSignals SignalOverflow = iota
+
+	// DropOldestSignal drops the oldest queued signal to make room.
+	DropOldestSignal
+
+	// EscalateSignals replaces every queued signal with a single os.Kill, on
+	// the grounds that a Runner which cannot keep up with its signals should stop.
+	EscalateSignals
+)
+
+/*
+WithSignalQueue bounds the number of signals which may be waiting to be
+delivered to a Process's Runner.  Signals are always delivered in the order
+they were sent, and Signal never blocks.  By default the queue is unbounded.
+*/
+func WithSignalQueue(size int, overflow SignalOverflow) Option {
+	return func(o *options) {
+		o.signalQueueSize = size
+		o.signalOverflow = overflow
+	}
+}
+
+type signalQueue struct {
+	lock     *sync.Mutex
+	signals  []os.Signal
+	size     int
+	overflow SignalOverflow
+	notify   chan struct{}
+}
+
+func newSignalQueue(size int, overflow SignalOverflow) *signalQueue {
+	return &signalQueue{
+		lock:     new(sync.Mutex),
+		size:     size,
+		overflow: overflow,
+		notify:   make(chan struct{}, 1),
+	}
+}
+
+func (q *signalQueue) push(signal os.Signal) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if q.size > 0 && len(q.signals) >= q.size {
+		switch q.overflow {
+		case CoalesceSignals:
+			for _, queued := range q.signals {
+				if queued == signal {
+					return
+				}
+			}
+			q.signals = q.signals[1:]
+		case DropOldestSignal:
+			q.signals = q.signals[1:]
+		case EscalateSignals:
+			q.signals = nil
+			signal = os.Kill
+		}
+	}
+
+	q.signals = append(q.signals, signal)
+
+	select {
+	case q.notify <- struct{}{}:
+	default:
+	}
+}
+
+func (q *signalQueue) pop() (os.Signal, bool) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if len(q.signals) == 0 {
+		return nil, false
+	}
+
+	signal := q.signals[0]
+	q.signals = q.signals[1:]
+	return signal, true
+}
+
+// deliver sends queued signals to the runner, in order, until the process exits.
+func (q *signalQueue) deliver(signals chan<- os.Signal, exited <-chan struct{}) {
+	for {
+		signal, ok := q.pop()
+		if !ok {
+			select {
+			case <-q.notify:
+				continue
+			case <-exited:
+				return
+			}
+		}
+
+		select {
+		case signals <- signal:
+		case <-exited:
+			return
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package ifrit_test
+
+import (
+	"os"
+	"syscall"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/tedsuo/ifrit"
+)
+
+var _ = Describe("Signal queue", func() {
+	var release chan struct{}
+	var received chan os.Signal
+	var runner ifrit.Runner
+
+	BeforeEach(func() {
+		release = make(chan struct{})
+		received = make(chan os.Signal, 10)
+
+		// runner ignores its signals until released, then records them until killed
+		runner = ifrit.RunFunc(func(signals <-chan os.Signal, ready chan<- struct{}) error {
+			close(ready)
+			<-release
+			for signal := range signals {
+				received <- signal
+				if signal == os.Kill {
+					return nil
+				}
+			}
+			return nil
+		})
+	})
+
+	receivedSignals := func() []os.Signal {
+		result := []os.Signal{}
+		for {
+			signal := <-received
+			result = append(result, signal)
+			if signal == os.Kill {
+				return result
+			}
+		}
+	}
+
+	It("delivers signals in the order they were sent", func() {
+		proc := ifrit.Invoke(runner)
+		proc.Signal(syscall.SIGHUP)
+		proc.Signal(syscall.SIGTERM)
+		proc.Signal(syscall.SIGUSR1)
+		proc.Signal(syscall.SIGUSR2)
+		proc.Signal(os.Kill)
+		close(release)
+
+		Ω(receivedSignals()).Should(Equal([]os.Signal{
+			syscall.SIGHUP, syscall.SIGTERM, syscall.SIGUSR1, syscall.SIGUSR2, os.Kill,
+		}))
+		Eventually(proc.Done()).Should(BeClosed())
+	})
+
+	Context("when the queue is bounded", func() {
+		It("drops the oldest signals on overflow with DropOldestSignal", func() {
+			proc := ifrit.Invoke(runner, ifrit.WithSignalQueue(1, ifrit.DropOldestSignal))
+			proc.Signal(syscall.SIGHUP)
+			proc.Signal(syscall.SIGUSR1)
+			proc.Signal(syscall.SIGUSR2)
+			proc.Signal(os.Kill)
+			close(release)
+
+			signals := receivedSignals()
+			Ω(signals).ShouldNot(ContainElement(syscall.SIGUSR1))
+			Ω(signals).ShouldNot(ContainElement(syscall.SIGUSR2))
+			Eventually(proc.Done()).Should(BeClosed())
+		})
+
+		It("does not queue duplicate signals with CoalesceSignals", func() {
+			proc := ifrit.Invoke(runner, ifrit.WithSignalQueue(2, ifrit.CoalesceSignals))
+			proc.Signal(syscall.SIGHUP)
+			proc.Signal(syscall.SIGUSR1)
+			proc.Signal(syscall.SIGUSR1)
+			proc.Signal(syscall.SIGUSR1)
+			proc.Signal(os.Kill)
+			close(release)
+
+			signals := receivedSignals()
+			Ω(signals[len(signals)-1]).Should(Equal(os.Kill))
+			count := 0
+			for _, signal := range signals {
+				if signal == syscall.SIGUSR1 {
+					count++
+				}
+			}
+			Ω(count).Should(BeNumerically("<=", 2))
+			Eventually(proc.Done()).Should(BeClosed())
+		})
+
+		It("replaces the queue with os.Kill on overflow with EscalateSignals", func() {
+			proc := ifrit.Invoke(runner, ifrit.WithSignalQueue(1, ifrit.EscalateSignals))
+			proc.Signal(syscall.SIGHUP)
+			proc.Signal(syscall.SIGUSR1)
+			proc.Signal(syscall.SIGUSR2)
+			close(release)
+
+			signals := receivedSignals()
+			Ω(signals).ShouldNot(ContainElement(syscall.SIGUSR1))
+			Ω(signals).ShouldNot(ContainElement(syscall.SIGUSR2))
+			Eventually(proc.Done()).Should(BeClosed())
+		})
+	})
+})
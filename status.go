@@ -0,0 +1,63 @@
+package ifrit
+
+import (
+	"os"
+	"time"
+)
+
+/*
+State describes the phase of a Process's lifecycle.
+*/
+type State int
+
+const (
+	// Starting processes are running, but have not yet become ready.
+	Starting State = iota
+
+	// Ready processes have closed their ready channel.
+	Ready
+
+	// Stopping processes have received a signal, but have not yet exited.
+	Stopping
+
+	// Exited processes have returned from Run.
+	Exited
+)
+
+func (s State) String() string {
+	switch s {
+	case Starting:
+		return "starting"
+	case Ready:
+		return "ready"
+	case Stopping:
+		return "stopping"
+	case Exited:
+		return "exited"
+	default:
+		return "unknown"
+	}
+}
+
+/*
+Status is a snapshot of a Process's lifecycle. Timestamps are zero until the
+corresponding event has occurred.
+*/
+type Status struct {
+	// Name is the Process's name, as given by WithName and ChildOf.
+	Name string
+
+	State     State
+	StartedAt time.Time
+	ReadyAt   time.Time
+	ExitedAt  time.Time
+
+	// Signals lists every signal sent to the Process before it exited, in order.
+	Signals []os.Signal
+
+	// Cause is the reason the Process was first signaled.  See SignalWithCause.
+	Cause error
+
+	// Err is the exit error of the Process. It is nil until the Process exits.
+	Err error
+}
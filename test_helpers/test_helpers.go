@@ -2,8 +2,10 @@ package test_helpers
 
 import (
 	"errors"
+	"fmt"
 	"os"
 	"sync"
+	"time"
 
 	"github.com/tedsuo/ifrit"
 )
@@ -83,3 +85,38 @@ func (r *SignalRecoder) ReceivedSignals() []os.Signal {
 	r.RLock()
 	return r.signals
 }
+
+// ObserverRecorder records the lifecycle events it observes as strings, such
+// as "start group/child1" or "exit child1".
+type ObserverRecorder struct {
+	sync.RWMutex
+	events []string
+}
+
+func (r *ObserverRecorder) record(event string, p ifrit.Process) {
+	r.Lock()
+	defer r.Unlock()
+	r.events = append(r.events, fmt.Sprintf("%s %s", event, p.Status().Name))
+}
+
+func (r *ObserverRecorder) OnStart(p ifrit.Process) {
+	r.record("start", p)
+}
+
+func (r *ObserverRecorder) OnReady(p ifrit.Process) {
+	r.record("ready", p)
+}
+
+func (r *ObserverRecorder) OnSignal(p ifrit.Process, signal os.Signal) {
+	r.record("signal", p)
+}
+
+func (r *ObserverRecorder) OnExit(p ifrit.Process, duration time.Duration, err error) {
+	r.record("exit", p)
+}
+
+func (r *ObserverRecorder) Events() []string {
+	defer r.RUnlock()
+	r.RLock()
+	return append([]string(nil), r.events...)
+}